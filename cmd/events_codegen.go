@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"abacatepay-cli/internal/codegen"
+	"abacatepay-cli/internal/mock"
+	"abacatepay-cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	codegenLang      string
+	codegenFramework string
+	codegenOutDir    string
+	codegenPackage   string
+	codegenOverwrite bool
+)
+
+var eventsCodegenCmd = &cobra.Command{
+	Use:   "codegen",
+	Short: "Generate typed webhook handler stubs for your stack",
+	Long: `Generate typed webhook handler stubs for your stack.
+
+The generated code contains the event type definitions, a signature verifier for
+the X-Abacate-Signature header, a router that dispatches each event to its handler
+and one handler stub per event (handlers.*) for you to fill in.
+
+Supported stacks:
+  --lang ts      --framework next (default), express, elysia
+  --lang go      --framework net/http (default)
+  --lang python  --framework fastapi (default)
+
+The types, verifier and router are regenerated on every run, so run the command
+again after a schema change to update them. Handler stubs are only written when
+they don't exist yet, keeping the code you filled in; --overwrite-handlers
+replaces them with fresh stubs.`,
+	Example: `  abacatepay events codegen --lang ts --framework next --out-dir app/api/webhooks/abacatepay
+  abacatepay events codegen --lang go --out-dir internal/abacatepay
+  abacatepay events codegen --lang python --framework fastapi --out-dir app/abacatepay`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return generateHandlers()
+	},
+}
+
+func init() {
+	eventsCodegenCmd.Flags().StringVar(&codegenLang, "lang", "ts", "Language: "+strings.Join(codegen.Languages(), ", "))
+	eventsCodegenCmd.Flags().StringVar(&codegenFramework, "framework", "", "Framework (default depends on --lang)")
+	eventsCodegenCmd.Flags().StringVar(&codegenOutDir, "out-dir", "abacatepay", "Directory to write the generated files to")
+	eventsCodegenCmd.Flags().StringVar(&codegenPackage, "package", "", "Go package name (default: the --out-dir name)")
+	eventsCodegenCmd.Flags().BoolVar(&codegenOverwrite, "overwrite-handlers", false, "Replace existing handler stubs with fresh ones")
+
+	eventsCmd.AddCommand(eventsCodegenCmd)
+}
+
+func generateHandlers() error {
+	definitions := mock.Events()
+
+	inputs := make([]codegen.Input, 0, len(definitions))
+	for _, def := range definitions {
+		s, err := eventSchema(def.Name)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, codegen.Input{Name: def.Name, Description: def.Description, Schema: s})
+	}
+
+	pkg := codegenPackage
+	if pkg == "" {
+		pkg = goPackageName(codegenOutDir)
+	}
+
+	files, err := codegen.Generate(codegen.Build(inputs), codegen.Options{
+		Lang:      codegenLang,
+		Framework: codegenFramework,
+		Package:   pkg,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(codegenOutDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", codegenOutDir, err)
+	}
+
+	written := make([]string, 0, len(files))
+	kept := []string{}
+	for _, f := range files {
+		path := filepath.Join(codegenOutDir, f.Path)
+		if f.Stub && !codegenOverwrite {
+			if _, err := os.Stat(path); err == nil {
+				kept = append(kept, path)
+				continue
+			}
+		}
+		if err := os.WriteFile(path, f.Content, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+
+	fields := map[string]string{
+		"Language":  codegenLang,
+		"Directory": codegenOutDir,
+		"Files":     strings.Join(written, ", "),
+		"Events":    fmt.Sprintf("%d", len(definitions)),
+		"Next":      "Fill in the handlers and set ABACATEPAY_WEBHOOK_SECRET",
+	}
+	if len(kept) > 0 {
+		fields["Kept"] = strings.Join(kept, ", ") + " (use --overwrite-handlers to replace)"
+		fields["Next"] = "Add handlers for new events, if any, and check the build"
+	}
+
+	output.Print(output.Result{
+		Title:  "Webhook Handlers Generated",
+		Fields: fields,
+		Data: map[string]any{
+			"lang":      codegenLang,
+			"framework": codegenFramework,
+			"directory": codegenOutDir,
+			"files":     written,
+			"kept":      kept,
+		},
+	})
+
+	return nil
+}
+
+// goPackageName derives a valid Go package name from a directory name.
+func goPackageName(dir string) string {
+	base := strings.ToLower(filepath.Base(filepath.Clean(dir)))
+
+	var b strings.Builder
+	for _, r := range base {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9' && b.Len() > 0) {
+			b.WriteRune(r)
+		}
+	}
+
+	if b.Len() == 0 {
+		return "abacatepay"
+	}
+	return b.String()
+}
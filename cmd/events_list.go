@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"abacatepay-cli/internal/mock"
+	"abacatepay-cli/internal/output"
+	"abacatepay-cli/internal/style"
+
+	"github.com/spf13/cobra"
+)
+
+var eventsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List every webhook event type supported by the CLI",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listEvents()
+	},
+}
+
+func init() {
+	eventsCmd.AddCommand(eventsListCmd)
+}
+
+func listEvents() error {
+	definitions := mock.Events()
+
+	if output.GetFormat() == output.FormatJSON {
+		items := make([]map[string]string, 0, len(definitions))
+		for _, def := range definitions {
+			items = append(items, map[string]string{
+				"event":       def.Name,
+				"description": def.Description,
+			})
+		}
+
+		style.PrintJSON(map[string]any{
+			"events": items,
+			"count":  len(items),
+		})
+		return nil
+	}
+
+	rows := make([][]string, 0, len(definitions))
+	for _, def := range definitions {
+		rows = append(rows, []string{def.Name, def.Description})
+	}
+
+	style.PrintTable([]string{"Event", "Description"}, rows)
+
+	return nil
+}
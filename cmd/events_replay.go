@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"abacatepay-cli/internal/output"
+	"abacatepay-cli/internal/recording"
+	"abacatepay-cli/internal/style"
+	"abacatepay-cli/internal/utils"
+	"abacatepay-cli/internal/webhook"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	replayForwardURL string
+	replaySpeed      string
+	replayNoDelay    bool
+	replaySecret     string
+	replayEvents     []string
+)
+
+var eventsReplayCmd = &cobra.Command{
+	Use:   "replay <recording.jsonl>",
+	Short: "Deliver the events of a recorded listen session again",
+	Long: `Deliver the events of a recorded listen session again.
+
+Recordings are created with 'abacatepay listen --record'. Events are delivered in
+the order they were received, re-signed with --secret and paced like the original
+session; --speed makes the replay faster (2x) or slower (0.5x) and --no-delay
+sends them back to back. No authentication is needed.`,
+	Example: `  abacatepay events replay session.jsonl
+  abacatepay events replay session.jsonl --forward-to http://localhost:3000/webhooks --speed 2x
+  abacatepay events replay fixtures/checkout.jsonl --no-delay --event billing.paid`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return replayRecording(args[0])
+	},
+}
+
+func init() {
+	eventsReplayCmd.Flags().StringVar(&replayForwardURL, "forward-to", "", "URL to deliver the events to")
+	eventsReplayCmd.Flags().StringVar(&replaySpeed, "speed", "1x", "Replay speed relative to the recording, e.g. 2x or 0.5x")
+	eventsReplayCmd.Flags().BoolVar(&replayNoDelay, "no-delay", false, "Send every event immediately, ignoring the recorded timing")
+	eventsReplayCmd.Flags().StringVar(&replaySecret, "secret", localSigningSecret, "Webhook signing secret")
+	eventsReplayCmd.Flags().StringSliceVar(&replayEvents, "event", nil, "Only replay these event types")
+
+	eventsCmd.AddCommand(eventsReplayCmd)
+}
+
+func replayRecording(path string) error {
+	speed, err := parseSpeed(replaySpeed)
+	if err != nil {
+		return err
+	}
+
+	entries, err := recording.Load(path)
+	if err != nil {
+		return err
+	}
+
+	entries = filterEntries(entries, replayEvents)
+	if len(entries) == 0 {
+		return fmt.Errorf("no events to replay in %s", path)
+	}
+
+	url, err := utils.GetForwardURL(replayForwardURL, utils.DefaultForwardURL)
+	if err != nil {
+		return err
+	}
+
+	deps := utils.SetupDependencies(Local, Verbose)
+
+	txLogger, err := utils.SetupTransactionLogger(deps.LogTags())
+	if err != nil {
+		return fmt.Errorf("failed to initialize transaction logger: %w", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	listener := webhook.NewListener(deps.Config, deps.Client, url, deps.Config.TokenKey, txLogger)
+	listener.SetSigningSecret(replaySecret)
+
+	style.LogSigningSecret(replaySecret)
+	fmt.Printf("Replaying %d events from %s to %s\n\n", len(entries), path, url)
+
+	var delivered, failed int
+	start := time.Now()
+
+	for i, e := range entries {
+		if i > 0 && !replayNoDelay {
+			wait := time.Duration(float64(e.Offset()-entries[i-1].Offset()) / speed)
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+			}
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		d, err := listener.Deliver(ctx, e.Payload)
+		if err != nil {
+			return err
+		}
+
+		if d.OK() {
+			delivered++
+		} else {
+			failed++
+		}
+	}
+
+	output.Print(output.Result{
+		Title: "Replay Finished",
+		Fields: map[string]string{
+			"Recording": path,
+			"URL":       url,
+			"Delivered": strconv.Itoa(delivered),
+			"Failed":    strconv.Itoa(failed),
+			"Duration":  time.Since(start).Round(time.Millisecond).String(),
+		},
+		Data: map[string]any{
+			"recording":  path,
+			"url":        url,
+			"events":     len(entries),
+			"delivered":  delivered,
+			"failed":     failed,
+			"durationMs": time.Since(start).Milliseconds(),
+		},
+	})
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d events were not accepted by %s", failed, delivered+failed, url)
+	}
+	return nil
+}
+
+// parseSpeed accepts "2x", "0.5x" or a bare multiplier.
+func parseSpeed(s string) (float64, error) {
+	speed, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), "x"), 64)
+	if err != nil || speed <= 0 {
+		return 0, fmt.Errorf("invalid speed %q. Expected something like 2x or 0.5x", s)
+	}
+	return speed, nil
+}
+
+func filterEntries(entries []recording.Entry, events []string) []recording.Entry {
+	if len(events) == 0 {
+		return entries
+	}
+
+	wanted := make(map[string]bool, len(events))
+	for _, e := range events {
+		wanted[e] = true
+	}
+
+	filtered := entries[:0]
+	for _, e := range entries {
+		if wanted[e.Event] {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
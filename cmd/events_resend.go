@@ -1,44 +1,119 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
-	"abacatepay-cli/internal/crypto"
 	"abacatepay-cli/internal/logger"
+	"abacatepay-cli/internal/output"
+	"abacatepay-cli/internal/payload"
+	"abacatepay-cli/internal/recording"
 	"abacatepay-cli/internal/style"
 	"abacatepay-cli/internal/utils"
+	"abacatepay-cli/internal/webhook"
 
 	"github.com/spf13/cobra"
 )
 
-var resendForwardURL string
+const localSigningSecret = "whsec_abacate_local_dev_secret"
+
+var (
+	resendForwardURL string
+	resendSince      string
+	resendEvents     []string
+	resendStatus     string
+	resendAllFrom    string
+	resendDryRun     bool
+	resendEdit       bool
+	resendSets       []string
+	resendSetsFile   string
+	resendCurrent    bool
+)
 
 var eventsResendCmd = &cobra.Command{
-	Use:   "resend <event-id>",
-	Short: "Resend a past event to your local webhook endpoint",
-	Args:  cobra.ExactArgs(1),
+	Use:   "resend [event-id]",
+	Short: "Resend past events to your local webhook endpoint",
+	Long: `Resend past events to your local webhook endpoint.
+
+With an event ID, that event is looked up in the local transaction log and sent
+again. Without one, every logged event matching the filters is redelivered in
+its original order and a summary of the outcomes is printed:
+
+  --since    only events received in the last duration (1h, 30m, 2d) or since a
+             date (2006-01-02 or RFC 3339)
+  --event    only these event types
+  --status   only events whose latest delivery was delivered, failed or pending
+  --all-from every event of a session recorded with 'listen --record'
+
+Each resend is logged, so an event that failed and is accepted on resend shows
+up as delivered afterwards.
+
+The payload can be changed before it is sent: --set path=value and --from-file
+override fields (see 'events sample --help'), and --edit opens a single event in
+$VISUAL or $EDITOR. Modified payloads are signed again like any other resend.
+Events logged with 'logs settings --redact' are refused until every masked
+field is given a value this way; in a batch they are reported as failed.
+
+Events are looked up in the local event store, which also holds the rotated
+and compressed log backups. --current-only reads just the current
+transactions.log instead.`,
+	Example: `  abacatepay events resend evt_x8Kf2
+  abacatepay events resend --since 24h --status failed
+  abacatepay events resend --since 1h --event billing.paid --forward-to http://localhost:3000/webhooks
+  abacatepay events resend --all-from session.jsonl --dry-run
+  abacatepay events resend evt_x8Kf2 --set data.billing.amount=1 --set data.billing.status=EXPIRED
+  abacatepay events resend evt_x8Kf2 --edit --forward-to http://localhost:4000/webhooks`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return resendEvent(args[0])
+		hasFilters := resendSince != "" || len(resendEvents) > 0 || resendStatus != "" || resendAllFrom != ""
+
+		if len(args) == 1 {
+			if hasFilters {
+				return fmt.Errorf("an event ID can't be combined with --since, --event, --status or --all-from")
+			}
+			return resendEvent(args[0])
+		}
+
+		if !hasFilters {
+			return fmt.Errorf("pass an event ID, or select events with --since, --event, --status or --all-from")
+		}
+		if resendEdit {
+			return fmt.Errorf("--edit can only be used when resending a single event, use --set to change many")
+		}
+		return resendMany()
 	},
 }
 
 func init() {
-	eventsResendCmd.Flags().StringVar(&resendForwardURL, "forward-to", "", "URL to forward the event to")
+	eventsResendCmd.Flags().StringVar(&resendForwardURL, "forward-to", "", "URL to forward the events to")
+	eventsResendCmd.Flags().StringVar(&resendSince, "since", "", "Only events received since a duration ago (1h, 2d) or a date")
+	eventsResendCmd.Flags().StringSliceVar(&resendEvents, "event", nil, "Only these event types, can be repeated")
+	eventsResendCmd.Flags().StringVar(&resendStatus, "status", "", "Only events whose latest delivery is "+strings.Join(logger.EventStatuses, ", ")+" or any")
+	eventsResendCmd.Flags().StringVar(&resendAllFrom, "all-from", "", "Resend every event of a recording made with 'listen --record'")
+	eventsResendCmd.Flags().BoolVar(&resendDryRun, "dry-run", false, "List the events that would be resent without sending them")
+	eventsResendCmd.Flags().BoolVar(&resendEdit, "edit", false, "Edit the payload in $EDITOR before sending it")
+	eventsResendCmd.Flags().StringArrayVar(&resendSets, "set", nil, "Override a payload field (path=value), can be repeated")
+	eventsResendCmd.Flags().StringVar(&resendSetsFile, "from-file", "", "JSON file with field overrides")
+	eventsResendCmd.Flags().BoolVar(&resendCurrent, "current-only", false, "Only look in the current log file, ignoring rotated backups")
+
 	eventsCmd.AddCommand(eventsResendCmd)
 }
 
 func resendEvent(id string) error {
-	entry, err := logger.FindLogEntryByID(id)
+	entry, err := logger.FindLogEntryByID(id, logger.ReadOptions{CurrentOnly: resendCurrent})
 	if err != nil {
 		return err
 	}
 
-	if entry.RawMessage == "" {
-		return fmt.Errorf("event %s found but has no raw payload stored", id)
-	}
-
 	defaultURL := utils.DefaultForwardURL
 	if entry.URL != "" {
 		defaultURL = entry.URL
@@ -49,43 +124,342 @@ func resendEvent(id string) error {
 		return err
 	}
 
-	deps := utils.SetupDependencies(Local, Verbose)
+	message, err := resendPayload(entry.RawMessage, resendEdit)
+	if err != nil {
+		return err
+	}
+	if err := checkRedacted(message); err != nil {
+		return fmt.Errorf("event %s: %w", id, err)
+	}
 
-	secret := "whsec_abacate_local_dev_secret"
-	timestamp := time.Now().Unix()
-	signature := crypto.SignWebhookPayload(secret, timestamp, []byte(entry.RawMessage))
+	listener, err := newResendListener(url)
+	if err != nil {
+		return err
+	}
 
-	style.LogSigningSecret(secret)
+	style.LogSigningSecret(localSigningSecret)
 	fmt.Printf("Resending event %s to %s...\n", id, url)
 
-	startTime := time.Now()
-	resp, err := deps.Client.R().
-		SetHeader("Content-Type", "application/json").
-		SetHeader("X-Abacate-Signature", fmt.Sprintf("t=%d,v1=%s", timestamp, signature)).
-		SetBody(entry.RawMessage).
-		Post(url)
+	d, err := listener.Forward(context.Background(), message)
+	if err != nil {
+		return err
+	}
 
-	duration := time.Since(startTime)
+	if d.Err != nil {
+		style.PrintError(fmt.Sprintf("Failed to forward: %v", d.Err))
+		return nil
+	}
+
+	if d.OK() {
+		fields := map[string]string{
+			"ID":       id,
+			"Status":   fmt.Sprintf("%d %s", d.StatusCode, http.StatusText(d.StatusCode)),
+			"Duration": fmt.Sprintf("%dms", d.Duration.Milliseconds()),
+		}
+		if string(message) != entry.RawMessage {
+			fields["Payload"] = "modified"
+		}
+
+		style.PrintSuccess("Event resent successfully", fields)
+
+		return nil
+	}
+
+	fmt.Printf("\nServer responded with error status: %d\n", d.StatusCode)
+	fmt.Println(string(d.Response))
+
+	return nil
+}
 
+func resendMany() error {
+	events, err := selectResendEvents()
 	if err != nil {
-		style.PrintError(fmt.Sprintf("Failed to forward: %v", err))
+		return err
+	}
+
+	if len(events) == 0 {
+		fmt.Println("No events match the given filters.")
 		return nil
 	}
 
-	style.LogWebhookForwarded(resp.StatusCode(), http.StatusText(resp.StatusCode()), entry.Event)
+	if resendDryRun {
+		printResendCandidates(events)
+		return nil
+	}
 
-	if resp.StatusCode() >= 200 && resp.StatusCode() < 300 {
-		style.PrintSuccess("Event resent successfully", map[string]string{
-			"ID":       id,
-			"Status":   fmt.Sprintf("%d %s", resp.StatusCode(), http.StatusText(resp.StatusCode())),
-			"Duration": fmt.Sprintf("%dms", duration.Milliseconds()),
+	defaultURL := utils.DefaultForwardURL
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].URL != "" {
+			defaultURL = events[i].URL
+			break
+		}
+	}
+
+	url, err := utils.GetForwardURL(resendForwardURL, defaultURL)
+	if err != nil {
+		return err
+	}
+
+	listener, err := newResendListener(url)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	style.LogSigningSecret(localSigningSecret)
+	fmt.Printf("Resending %d events to %s...\n\n", len(events), url)
+
+	deliveries := make([]webhook.Delivery, 0, len(events))
+	for _, e := range events {
+		if ctx.Err() != nil {
+			break
+		}
+
+		message, err := resendPayload(e.RawMessage, false)
+		if err != nil {
+			return fmt.Errorf("event %s: %w", e.ID, err)
+		}
+		if err := checkRedacted(message); err != nil {
+			deliveries = append(deliveries, webhook.Delivery{ID: e.ID, Event: e.Event, URL: url, Err: err})
+			continue
+		}
+
+		d, err := listener.Forward(ctx, message)
+		if err != nil {
+			d = webhook.Delivery{ID: e.ID, Event: e.Event, URL: url, Err: err}
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return printResendSummary(deliveries, len(events))
+}
+
+// selectResendEvents returns the events chosen by the filters, oldest first.
+func selectResendEvents() ([]logger.LoggedEvent, error) {
+	filter := logger.EventFilter{Events: resendEvents, Status: resendStatus, CurrentOnly: resendCurrent}
+
+	if resendStatus != "" && resendStatus != "any" && !isEventStatus(resendStatus) {
+		return nil, fmt.Errorf("invalid status %q (valid: %s, any)", resendStatus, strings.Join(logger.EventStatuses, ", "))
+	}
+
+	if resendSince != "" {
+		since, err := parseTimeFlag("--since", resendSince, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		filter.Since = since
+	}
+
+	if resendAllFrom == "" {
+		return logger.ReadEvents(filter)
+	}
+
+	if resendStatus != "" {
+		return nil, fmt.Errorf("--status can't be used with --all-from, recordings have no delivery status")
+	}
+
+	entries, err := recording.Load(resendAllFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]logger.LoggedEvent, 0, len(entries))
+	for _, entry := range entries {
+		events = append(events, logger.LoggedEvent{
+			ID:         entry.ID,
+			Event:      entry.Event,
+			Time:       entry.ReceivedAt,
+			RawMessage: string(entry.Payload),
 		})
+	}
+
+	return logger.FilterEvents(events, filter), nil
+}
+
+// resendPayload applies --from-file and --set overrides to a logged payload and
+// optionally lets the user edit the result. Unmodified payloads are sent byte for byte.
+func resendPayload(raw string, edit bool) ([]byte, error) {
+	if !edit && len(resendSets) == 0 && resendSetsFile == "" {
+		return []byte(raw), nil
+	}
+
+	doc, err := payload.Decode([]byte(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	var assignments []payload.Assignment
+	if resendSetsFile != "" {
+		assignments, err = payload.LoadAssignments(resendSetsFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	flagAssignments, err := payload.ParseAssignments(resendSets)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range append(assignments, flagAssignments...) {
+		if err := payload.MergeAt(doc, a.Path, a.Value); err != nil {
+			return nil, err
+		}
+	}
 
+	if !edit {
+		return json.Marshal(doc)
+	}
+
+	pretty, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	edited, err := utils.EditInEditor(append(pretty, '\n'), "abacatepay-event-*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bytes.TrimSpace(edited)) == 0 {
+		return nil, fmt.Errorf("the payload is empty, resend aborted")
+	}
+
+	editedDoc, err := payload.Decode(edited)
+	if err != nil {
+		return nil, fmt.Errorf("edited payload: %w", err)
+	}
+
+	return json.Marshal(editedDoc)
+}
+
+// checkRedacted refuses payloads that still hold values masked by log
+// redaction, which would reach the app in place of the real data.
+func checkRedacted(message []byte) error {
+	paths := logger.RedactedPaths(string(message))
+	if len(paths) == 0 {
 		return nil
 	}
+	return fmt.Errorf("payload was logged with redacted fields (%s), set them with --set or --from-file to resend it", strings.Join(paths, ", "))
+}
+
+func newResendListener(url string) (*webhook.Listener, error) {
+	deps := utils.SetupDependencies(Local, Verbose)
+
+	txLogger, err := utils.SetupTransactionLogger(deps.LogTags())
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize transaction logger: %w", err)
+	}
+
+	listener := webhook.NewListener(deps.Config, deps.Client, url, deps.Config.TokenKey, txLogger)
+	listener.SetSigningSecret(localSigningSecret)
+
+	return listener, nil
+}
+
+func printResendCandidates(events []logger.LoggedEvent) {
+	if output.GetFormat() == output.FormatJSON {
+		style.PrintJSON(map[string]any{
+			"events": events,
+			"count":  len(events),
+		})
+		return
+	}
+
+	rows := make([][]string, 0, len(events))
+	for _, e := range events {
+		rows = append(rows, []string{e.Time.Local().Format(time.DateTime), e.ID, e.Event, e.Status})
+	}
+
+	style.PrintTable([]string{"Received", "ID", "Event", "Last Status"}, rows)
+	fmt.Printf("\n%d events would be resent.\n", len(events))
+}
+
+func printResendSummary(deliveries []webhook.Delivery, selected int) error {
+	var succeeded, failed int
+
+	items := make([]map[string]any, 0, len(deliveries))
+	rows := make([][]string, 0, len(deliveries))
+
+	for _, d := range deliveries {
+		result := "ok"
+		status := strconv.Itoa(d.StatusCode)
+
+		switch {
+		case d.Err != nil:
+			result = d.Err.Error()
+			status = "-"
+			failed++
+		case !d.OK():
+			result = http.StatusText(d.StatusCode)
+			failed++
+		default:
+			succeeded++
+		}
 
-	fmt.Printf("\nServer responded with error status: %d\n", resp.StatusCode())
-	fmt.Println(string(resp.Body()))
+		rows = append(rows, []string{d.ID, d.Event, status, fmt.Sprintf("%dms", d.Duration.Milliseconds()), result})
 
+		item := map[string]any{
+			"id":         d.ID,
+			"event":      d.Event,
+			"statusCode": d.StatusCode,
+			"durationMs": d.Duration.Milliseconds(),
+			"ok":         d.OK(),
+		}
+		if d.Err != nil {
+			item["error"] = d.Err.Error()
+		}
+		items = append(items, item)
+	}
+
+	if output.GetFormat() == output.FormatJSON {
+		style.PrintJSON(map[string]any{
+			"results":   items,
+			"selected":  selected,
+			"succeeded": succeeded,
+			"failed":    failed,
+		})
+	} else {
+		fmt.Println()
+		style.PrintTable([]string{"ID", "Event", "Status", "Duration", "Result"}, rows)
+		fmt.Printf("\n%d resent, %d succeeded, %d failed.\n", len(deliveries), succeeded, failed)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d events were not accepted", failed, len(deliveries))
+	}
 	return nil
 }
+
+// parseTimeFlag accepts a duration before now (90m, 1h, 2d) or a date
+// (2006-01-02, 2006-01-02 15:04 or RFC 3339).
+func parseTimeFlag(flag, value string, now time.Time) (time.Time, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04", time.DateOnly} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid %s %q. Expected a duration (1h, 2d) or a date (2006-01-02)", flag, value)
+}
+
+func isEventStatus(status string) bool {
+	for _, s := range logger.EventStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -1,44 +1,77 @@
 package cmd
 
 import (
-	"fmt"
-
 	"abacatepay-cli/internal/mock"
+	"abacatepay-cli/internal/payload"
 	"abacatepay-cli/internal/style"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	sampleOverrides     []string
+	sampleOverridesFile string
+)
+
 var eventsSampleCmd = &cobra.Command{
-	Use:       "sample <event>",
-	Short:     "Generate a sample JSON payload for a specific event",
-	Example:   "abacatepay events billing.paid\n  abacatepay events payout.done",
+	Use:   "sample <event>",
+	Short: "Generate a sample JSON payload for a specific event",
+	Long: `Generate a sample JSON payload for a specific event.
+
+Fields can be overridden with --set path=value, where path is a dotted path into
+the payload and value is parsed as JSON when possible (5000, true, {"a":1}) and
+used as a plain string otherwise. --from-file applies a JSON object of overrides
+before any --set flag.`,
+	Example: `  abacatepay events sample billing.paid
+  abacatepay events sample billing.paid --set data.billing.amount=5000 --set data.billing.externalId=order_42
+  abacatepay events sample subscription.created --from-file overrides.json`,
 	Args:      cobra.ExactArgs(1),
-	ValidArgs: []string{"billing.paid", "payout.done", "payout.failed"},
+	ValidArgs: mock.EventNames(),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return events(args[0])
 	},
 }
 
 func init() {
+	eventsSampleCmd.Flags().StringArrayVar(&sampleOverrides, "set", nil, "Override a payload field (path=value), can be repeated")
+	eventsSampleCmd.Flags().StringVar(&sampleOverridesFile, "from-file", "", "JSON file with field overrides")
+
 	eventsCmd.AddCommand(eventsSampleCmd)
 }
 
 func events(evt string) error {
-	var data any
-
-	switch evt {
-	case "billing.paid":
-		data = mock.MockBillingPaidEvent()
-	case "payout.done":
-		data = mock.MockPayoutEvent(true)
-	case "payout.failed":
-		data = mock.MockPayoutEvent(false)
-	default:
-		return fmt.Errorf("unknown event type: %s. Available: billing.paid, payout.done, payout.failed", evt)
+	data, err := generateEvent(evt, sampleOverrides, sampleOverridesFile)
+	if err != nil {
+		return err
 	}
 
 	style.PrintJSON(data)
 
 	return nil
 }
+
+func generateEvent(evt string, sets []string, overridesFile string) (any, error) {
+	event, err := mock.GenerateEvent(evt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sets) == 0 && overridesFile == "" {
+		return event, nil
+	}
+
+	var assignments []payload.Assignment
+	if overridesFile != "" {
+		assignments, err = payload.LoadAssignments(overridesFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	flagAssignments, err := payload.ParseAssignments(sets)
+	if err != nil {
+		return nil, err
+	}
+
+	return payload.Apply(event, append(assignments, flagAssignments...))
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"abacatepay-cli/internal/mock"
+	"abacatepay-cli/internal/output"
+	"abacatepay-cli/internal/schema"
+	"abacatepay-cli/internal/style"
+
+	"github.com/spf13/cobra"
+)
+
+var schemaOutDir string
+
+var eventsSchemaCmd = &cobra.Command{
+	Use:   "schema [event]",
+	Short: "Print the JSON Schema of an event payload",
+	Long: `Print the JSON Schema (draft 2020-12) of an event payload.
+
+Without an event, the schemas of every event in the catalog are printed as a
+single object keyed by event name. With --out-dir one <event>.json file is
+written per event instead, ready for tools such as json-schema-to-typescript.`,
+	Example: `  abacatepay events schema billing.paid
+  abacatepay events schema --out-dir ./schemas`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: mock.EventNames(),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names := mock.EventNames()
+		if len(args) == 1 {
+			names = args
+		}
+		return printSchemas(names, len(args) == 1)
+	},
+}
+
+func init() {
+	eventsSchemaCmd.Flags().StringVar(&schemaOutDir, "out-dir", "", "Write one <event>.json schema file per event to this directory")
+
+	eventsCmd.AddCommand(eventsSchemaCmd)
+}
+
+func printSchemas(names []string, single bool) error {
+	schemas := make(map[string]*schema.Schema, len(names))
+	for _, name := range names {
+		s, err := eventSchema(name)
+		if err != nil {
+			return err
+		}
+		schemas[name] = s
+	}
+
+	if schemaOutDir == "" {
+		if single {
+			style.PrintJSON(schemas[names[0]])
+		} else {
+			style.PrintJSON(schemas)
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(schemaOutDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", schemaOutDir, err)
+	}
+
+	files := make([]string, 0, len(names))
+	for _, name := range names {
+		data, err := json.MarshalIndent(schemas[name], "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode schema for %s: %w", name, err)
+		}
+
+		path := filepath.Join(schemaOutDir, name+".json")
+		if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		files = append(files, path)
+	}
+
+	output.Print(output.Result{
+		Title: "Schemas Written",
+		Fields: map[string]string{
+			"Directory": schemaOutDir,
+			"Schemas":   fmt.Sprintf("%d", len(files)),
+		},
+		Data: map[string]any{
+			"directory": schemaOutDir,
+			"files":     files,
+		},
+	})
+
+	return nil
+}
+
+func eventSchema(name string) (*schema.Schema, error) {
+	def, ok := mock.LookupEvent(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown event type: %s. Available: %s", name, strings.Join(mock.EventNames(), ", "))
+	}
+	return schema.ForEvent(def.Name, def.Description, def.Generate()), nil
+}
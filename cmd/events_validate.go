@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"abacatepay-cli/internal/output"
+	"abacatepay-cli/internal/schema"
+	"abacatepay-cli/internal/style"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	validateEvent  string
+	validateStrict bool
+)
+
+var eventsValidateCmd = &cobra.Command{
+	Use:   "validate <file>",
+	Short: "Validate captured webhook payloads against the event schemas",
+	Long: `Validate captured webhook payloads against the event schemas.
+
+The file may hold a single JSON payload or several, one per line. Each payload is
+checked against the schema of its "event" field unless --event is given. Use "-"
+to read from stdin.
+
+With --strict, fields that aren't part of the schema are reported as errors, which
+catches payload changes before they reach your handlers. The command exits with a
+non-zero status when any payload is invalid.`,
+	Example: `  abacatepay events validate captured.json
+  abacatepay events validate webhooks.jsonl --strict
+  cat payload.json | abacatepay events validate - --event billing.paid`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return validatePayloads(args[0])
+	},
+}
+
+func init() {
+	eventsValidateCmd.Flags().StringVar(&validateEvent, "event", "", "Validate every payload against this event instead of its \"event\" field")
+	eventsValidateCmd.Flags().BoolVar(&validateStrict, "strict", false, "Report fields that aren't part of the schema")
+
+	eventsCmd.AddCommand(eventsValidateCmd)
+}
+
+type payloadValidation struct {
+	Index  int                      `json:"index"`
+	Event  string                   `json:"event"`
+	ID     string                   `json:"id,omitempty"`
+	Valid  bool                     `json:"valid"`
+	Errors []schema.ValidationError `json:"errors,omitempty"`
+}
+
+func validatePayloads(path string) error {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	var results []payloadValidation
+	for i := 1; ; i++ {
+		var doc any
+		if err := dec.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return fmt.Errorf("payload %d is not valid JSON: %w", i, err)
+		}
+
+		result, err := validatePayload(i, doc)
+		if err != nil {
+			return err
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		return fmt.Errorf("no payloads found in %s", path)
+	}
+
+	invalid := 0
+	for _, r := range results {
+		if !r.Valid {
+			invalid++
+		}
+	}
+
+	printValidations(results, invalid)
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d payloads don't match their schema", invalid, len(results))
+	}
+	return nil
+}
+
+func validatePayload(index int, doc any) (payloadValidation, error) {
+	result := payloadValidation{Index: index, Event: validateEvent}
+
+	obj, isObject := doc.(map[string]any)
+	if isObject {
+		if id, ok := obj["id"].(string); ok {
+			result.ID = id
+		}
+		if result.Event == "" {
+			result.Event, _ = obj["event"].(string)
+		}
+	}
+
+	if result.Event == "" {
+		return result, fmt.Errorf("payload %d has no \"event\" field, pass --event to choose a schema", index)
+	}
+
+	s, err := eventSchema(result.Event)
+	if err != nil {
+		return result, fmt.Errorf("payload %d: %w", index, err)
+	}
+
+	result.Errors = s.Validate(doc, validateStrict)
+	result.Valid = len(result.Errors) == 0
+
+	return result, nil
+}
+
+func printValidations(results []payloadValidation, invalid int) {
+	if output.GetFormat() == output.FormatJSON {
+		style.PrintJSON(map[string]any{
+			"payloads": results,
+			"count":    len(results),
+			"invalid":  invalid,
+		})
+		return
+	}
+
+	if len(results) == 1 && results[0].Valid {
+		r := results[0]
+		output.Print(output.Result{
+			Title: "Payload Valid",
+			Fields: map[string]string{
+				"Event": r.Event,
+				"ID":    r.ID,
+			},
+		})
+		return
+	}
+
+	rows := make([][]string, 0, len(results))
+	for _, r := range results {
+		status := "valid"
+		if !r.Valid {
+			status = "invalid"
+		}
+
+		if len(r.Errors) == 0 {
+			rows = append(rows, []string{strconv.Itoa(r.Index), r.Event, r.ID, status, ""})
+			continue
+		}
+
+		for i, e := range r.Errors {
+			if i == 0 {
+				rows = append(rows, []string{strconv.Itoa(r.Index), r.Event, r.ID, status, e.Error()})
+			} else {
+				rows = append(rows, []string{"", "", "", "", e.Error()})
+			}
+		}
+	}
+
+	style.PrintTable([]string{"#", "Event", "ID", "Result", "Problem"}, rows)
+
+	if invalid == 0 {
+		fmt.Printf("\nAll %d payloads are valid.\n", len(results))
+	} else {
+		fmt.Printf("\n%d of %d payloads are invalid.\n", invalid, len(results))
+	}
+}
@@ -2,11 +2,16 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
 
+	"abacatepay-cli/internal/config"
+	"abacatepay-cli/internal/recording"
 	"abacatepay-cli/internal/utils"
+	"abacatepay-cli/internal/webhook"
 
 	"github.com/spf13/cobra"
 )
@@ -14,19 +19,28 @@ import (
 var listenCmd = &cobra.Command{
 	Use:   "listen",
 	Short: "Listen for webhooks and forward them to your local app",
+	Long: `Listen for webhooks and forward them to your local app.
+
+With --record every received event (payload, timing and forwarded headers) is
+also written to a JSON lines file that can be shared or checked in as a test
+fixture, and delivered again later with 'abacatepay events replay'.`,
+	Example: `  abacatepay listen --forward-to http://localhost:3000/webhooks
+  abacatepay listen --record fixtures/checkout.jsonl`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return listen(cmd)
 	},
 }
 
 var (
-	forwardURL string
-	listenMock bool
+	forwardURL   string
+	listenMock   bool
+	listenRecord string
 )
 
 func init() {
 	listenCmd.Flags().StringVar(&forwardURL, "forward-to", "", "Where incoming events should be sent")
 	listenCmd.Flags().BoolVar(&listenMock, "mock", false, "Simulate incoming webhooks without connecting to the API")
+	listenCmd.Flags().StringVar(&listenRecord, "record", "", "Record received events to a JSON lines file for 'events replay'")
 
 	rootCmd.AddCommand(listenCmd)
 }
@@ -54,7 +68,42 @@ func listen(cmd *cobra.Command) error {
 		Token:      deps.Config.TokenKey,
 		Version:    cmd.Root().Version,
 		Mock:       listenMock,
+		LogTags:    deps.LogTags(),
+	}
+	if listenMock {
+		params.LogTags.Env = config.EnvOffline
+	}
+
+	if listenRecord == "" {
+		return utils.StartListener(params)
 	}
 
-	return utils.StartListener(params)
+	recorder, err := recording.Create(listenRecord)
+	if err != nil {
+		return err
+	}
+	defer recorder.Close()
+
+	// Events are recorded as they arrive, before forwarding, so a slow or failing
+	// app doesn't drop them from the recording or change their order.
+	params.Hooks.OnReceive = func(d webhook.Delivery, message []byte) {
+		err := recorder.Add(recording.Entry{
+			Event:      d.Event,
+			ID:         d.ID,
+			ReceivedAt: d.ReceivedAt,
+			Headers:    d.Headers,
+			Payload:    message,
+		})
+		if err != nil {
+			slog.Error("Failed to record event", "id", d.ID, "error", err)
+		}
+	}
+
+	slog.Info("Recording events", "file", listenRecord)
+
+	err = utils.StartListener(params)
+
+	fmt.Fprintf(os.Stderr, "Recorded %d events to %s\n", recorder.Count(), listenRecord)
+
+	return err
 }
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"abacatepay-cli/internal/config"
+	"abacatepay-cli/internal/logger"
+	"abacatepay-cli/internal/version"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFormat  string
+	exportOut     string
+	exportSince   string
+	exportUntil   string
+	exportEvents  []string
+	exportCurrent bool
+	exportProfile string
+	exportEnv     string
+)
+
+var logsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export transaction logs as HAR, CSV or NDJSON",
+	Long: `Export the local transaction log.
+
+  har     every forward attempt as an HTTP request, for browser dev tools or bug reports
+  csv     one row per log entry, for spreadsheets
+  ndjson  one JSON object per log entry, for log tooling
+
+The export is written to stdout unless --out is given.`,
+	Example: `  abacatepay logs export --format har --since 1h --out session.har
+  abacatepay logs export --format csv --since 2026-01-01 --until 2026-02-01 > january.csv
+  abacatepay logs export --event billing.paid | jq .`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return exportLogs()
+	},
+}
+
+func init() {
+	logsExportCmd.Flags().StringVar(&exportFormat, "format", "ndjson", "Export format: har, csv, ndjson")
+	logsExportCmd.Flags().StringVar(&exportOut, "out", "", "File to write the export to (default: stdout)")
+	logsExportCmd.Flags().StringVar(&exportSince, "since", "", "Only entries since a duration ago (1h, 2d) or a date")
+	logsExportCmd.Flags().StringVar(&exportUntil, "until", "", "Only entries until a duration ago (1h, 2d) or a date")
+	logsExportCmd.Flags().StringSliceVar(&exportEvents, "event", nil, "Only these event types, can be repeated")
+	logsExportCmd.Flags().StringVar(&exportProfile, "profile", "", "Only entries logged under this profile")
+	logsExportCmd.Flags().StringVar(&exportEnv, "env", "", "Only entries of an environment ("+config.EnvProduction+", "+config.EnvSandbox+", "+config.EnvOffline+")")
+	logsExportCmd.Flags().BoolVar(&exportCurrent, "current-only", false, "Only read the current log file, ignoring rotated backups")
+
+	logsCmd.AddCommand(logsExportCmd)
+}
+
+func exportLogs() error {
+	if !slices.Contains(logger.ExportFormats, exportFormat) {
+		return fmt.Errorf("invalid export format %q (valid: %s)", exportFormat, strings.Join(logger.ExportFormats, ", "))
+	}
+	if err := validateEnvFlag(exportEnv); err != nil {
+		return err
+	}
+
+	opts := logger.ReadOptions{CurrentOnly: exportCurrent}
+
+	now := time.Now()
+	if exportSince != "" {
+		since, err := parseTimeFlag("--since", exportSince, now)
+		if err != nil {
+			return err
+		}
+		opts.Since = since
+	}
+	if exportUntil != "" {
+		until, err := parseTimeFlag("--until", exportUntil, now)
+		if err != nil {
+			return err
+		}
+		opts.Until = until
+	}
+
+	entries, err := logger.SearchLogs(logger.Query{Profile: exportProfile, Env: exportEnv, Events: exportEvents}, opts)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if exportOut != "" {
+		f, err := os.Create(exportOut)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", exportOut, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := logger.Export(w, exportFormat, entries, version.Version); err != nil {
+		return err
+	}
+
+	if exportOut != "" {
+		fmt.Fprintf(os.Stderr, "Exported %d log entries to %s\n", len(entries), exportOut)
+	}
+	return nil
+}
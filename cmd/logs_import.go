@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+
+	"abacatepay-cli/internal/logger"
+	"abacatepay-cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var logsImportCmd = &cobra.Command{
+	Use:   "import [file...]",
+	Short: "Import transaction log files into the local event store",
+	Long:  "Copy entries from transaction log files into the indexed event store. Entries already stored are skipped, so importing the same file twice is safe. Without arguments every transaction log and its rotated backups are imported.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return importLogs(args)
+	},
+}
+
+func init() {
+	logsCmd.AddCommand(logsImportCmd)
+}
+
+func importLogs(files []string) error {
+	if len(files) == 0 {
+		var err error
+		files, err = logger.AllLogFiles()
+		if err != nil {
+			return err
+		}
+	}
+
+	store, err := logger.OpenStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	var total logger.ImportResult
+	for _, file := range files {
+		result, err := store.ImportFile(file)
+		if err != nil {
+			return err
+		}
+		total.Files += result.Files
+		total.Lines += result.Lines
+		total.Imported += result.Imported
+		total.Skipped += result.Skipped
+	}
+
+	storePath, _ := logger.GetStorePath()
+
+	output.Print(output.Result{
+		Title: "Logs imported",
+		Fields: map[string]string{
+			"Files":    fmt.Sprintf("%d", total.Files),
+			"Lines":    fmt.Sprintf("%d", total.Lines),
+			"Imported": fmt.Sprintf("%d", total.Imported),
+			"Skipped":  fmt.Sprintf("%d", total.Skipped),
+			"Store":    storePath,
+		},
+		Data: total,
+	})
+	return nil
+}
@@ -1,8 +1,17 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
 
+	"abacatepay-cli/internal/config"
 	"abacatepay-cli/internal/logger"
 	"abacatepay-cli/internal/output"
 	"abacatepay-cli/internal/style"
@@ -11,49 +20,324 @@ import (
 )
 
 var (
-	logsLimit      int
-	logsTypeFilter string
+	logsLimit       int
+	logsTypeFilter  string
+	logsCurrent     bool
+	logsEvents      []string
+	logsResource    string
+	logsStatusCodes string
+	logsSince       string
+	logsUntil       string
+	logsURL         string
+	logsMinDuration time.Duration
+	logsSearch      string
+	logsWhere       []string
+	logsEntries     bool
+	logsFollow      bool
+	logsProfile     string
+	logsEnv         string
 )
 
 var logsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List historical webhook events from local log file",
-	Long:  "Display webhook transactions recorded locally during listen sessions",
+	Long: `Display webhook transactions recorded locally during listen sessions, including
+the rotated and compressed log backups.
+
+Each row is one delivery: an event received and forwarded, or a resend, with the
+forward status, latency and target. --entries lists the raw log lines instead.
+Use 'logs show <id>' to see the full timeline of an event.
+
+--follow keeps printing new entries as they are written to the local log,
+including those of 'listen' sessions running in other terminals. The same
+filters apply; with -o json each new entry is printed as a JSON line.`,
+	Example: `  abacatepay logs list --event billing.paid --since 2h
+  abacatepay logs list --status-code 5xx --url localhost:3000
+  abacatepay logs list --resource bill_12345 --until 2026-01-31
+  abacatepay logs list --min-duration 500ms --search "john@"
+  abacatepay logs list --where 'data.billing.amount > 10000' --where 'data.billing.status == PAID'
+  abacatepay logs list --follow --event billing.paid --status-code 5xx
+  abacatepay logs list --profile staging --env sandbox`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return listLogs()
+		return listLogs(cmd)
 	},
 }
 
 func init() {
-	logsListCmd.Flags().IntVarP(&logsLimit, "limit", "n", 50, "Number of log entries to display")
+	logsListCmd.Flags().IntVarP(&logsLimit, "limit", "n", 50, "Number of deliveries (or entries with --entries) to display")
 	logsListCmd.Flags().StringVarP(&logsTypeFilter, "type", "t", "", "Filter by log type (webhook_received, webhook_forwarded, webhook_forward_failed, webhook_forward_error)")
+	logsListCmd.Flags().BoolVar(&logsCurrent, "current-only", false, "Only read the current log file, ignoring rotated backups")
+	logsListCmd.Flags().StringVar(&logsProfile, "profile", "", "Only entries logged under this profile")
+	logsListCmd.Flags().StringVar(&logsEnv, "env", "", "Only entries of an environment ("+config.EnvProduction+", "+config.EnvSandbox+", "+config.EnvOffline+")")
+	logsListCmd.Flags().StringSliceVar(&logsEvents, "event", nil, "Only these event types, can be repeated")
+	logsListCmd.Flags().StringVar(&logsResource, "resource", "", "Only events with this event or resource ID (e.g. a billing ID)")
+	logsListCmd.Flags().StringVar(&logsStatusCodes, "status-code", "", "Only forwards with a status code in a range (404, 5xx, 400-499, >=400)")
+	logsListCmd.Flags().StringVar(&logsSince, "since", "", "Only entries since a duration ago (1h, 2d) or a date")
+	logsListCmd.Flags().StringVar(&logsUntil, "until", "", "Only entries until a duration ago (1h, 2d) or a date")
+	logsListCmd.Flags().StringVar(&logsURL, "url", "", "Only forwards to URLs containing this text")
+	logsListCmd.Flags().DurationVar(&logsMinDuration, "min-duration", 0, "Only forwards that took at least this long (e.g. 500ms)")
+	logsListCmd.Flags().StringVar(&logsSearch, "search", "", "Only events whose payload contains this text")
+	logsListCmd.Flags().StringArrayVar(&logsWhere, "where", nil, "Only events whose payload matches a condition (path op value), can be repeated")
+	logsListCmd.Flags().BoolVar(&logsEntries, "entries", false, "List raw log entries instead of deliveries")
+	logsListCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "Keep printing new entries as they are logged, by this or any other process")
 
 	logsCmd.AddCommand(logsListCmd)
 }
 
-func listLogs() error {
+func listLogs(cmd *cobra.Command) error {
 	opts := logger.ReadOptions{
-		Limit:      logsLimit,
-		TypeFilter: logsTypeFilter,
+		Limit:       logsLimit,
+		TypeFilter:  logsTypeFilter,
+		CurrentOnly: logsCurrent,
+	}
+
+	now := time.Now()
+	if logsSince != "" {
+		since, err := parseTimeFlag("--since", logsSince, now)
+		if err != nil {
+			return err
+		}
+		opts.Since = since
+	}
+	if logsUntil != "" {
+		until, err := parseTimeFlag("--until", logsUntil, now)
+		if err != nil {
+			return err
+		}
+		opts.Until = until
 	}
 
-	entries, err := logger.ReadTransactionLogs(opts)
+	query, err := logsQuery()
 	if err != nil {
 		return err
 	}
 
-	if len(entries) == 0 {
-		logPath, _ := logger.GetLogFilePath()
-		fmt.Printf("No transaction logs found.\n")
-		fmt.Printf("Hint: Run 'abacatepay listen' to start recording webhook events.\n")
-		fmt.Printf("Log file location: %s\n", logPath)
-		return nil
+	if logsFollow {
+		if !opts.Until.IsZero() {
+			return fmt.Errorf("--until can't be combined with --follow")
+		}
+		if logsCurrent {
+			return fmt.Errorf("--current-only can't be combined with --follow")
+		}
+		return followLogs(cmd, query, opts)
+	}
+
+	if logsEntries {
+		entries, err := logger.SearchLogs(query, opts)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return printNoLogs(cmd)
+		}
+
+		if output.GetFormat() == output.FormatJSON {
+			return printLogsJSON(entries)
+		}
+		return printLogsTable(entries)
+	}
+
+	deliveries, err := logger.SearchDeliveries(query, opts)
+	if err != nil {
+		return err
+	}
+	if len(deliveries) == 0 {
+		return printNoLogs(cmd)
 	}
 
 	if output.GetFormat() == output.FormatJSON {
-		return printLogsJSON(entries)
+		style.PrintJSON(map[string]any{
+			"deliveries": deliveries,
+			"count":      len(deliveries),
+		})
+		return nil
+	}
+	printDeliveriesTable(deliveries)
+	return nil
+}
+
+// followLogs prints the matching history like 'logs list --entries' and then
+// every new matching entry until interrupted.
+func followLogs(cmd *cobra.Command, query logger.Query, opts logger.ReadOptions) error {
+	started := time.Now()
+	follower, err := logger.NewFollower()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	history, err := logger.SearchLogs(query, opts)
+	if err != nil {
+		return err
+	}
+
+	jsonLines := output.GetFormat() == output.FormatJSON
+	match := query.Matcher()
+
+	emit := func(e logger.LogEntry) {
+		if jsonLines {
+			data, _ := json.Marshal(e)
+			fmt.Println(string(data))
+			return
+		}
+		printFollowedEntry(e)
+	}
+
+	// The follower starts at the end of the files before the history is read,
+	// so nothing written in between is missed, but entries written in between
+	// are in both. They are skipped the first time the follower sees them. A
+	// line can be written a moment after its time, hence the margin.
+	seen := map[followedKey]bool{}
+	for _, e := range history {
+		match(e)
+		emit(e)
+		if !e.ParsedTime().Before(started.Add(-time.Second)) {
+			seen[followedKeyOf(e)] = true
+		}
+	}
+
+	if !jsonLines {
+		fmt.Fprintln(os.Stderr, "Following new log entries... Press Ctrl+C to stop")
+	}
+
+	return follower.Run(ctx, 500*time.Millisecond, func(e logger.LogEntry) {
+		if key := followedKeyOf(e); seen[key] {
+			delete(seen, key)
+			return
+		}
+		if !match(e) {
+			return
+		}
+		if logsTypeFilter != "" && e.Msg != logsTypeFilter {
+			return
+		}
+		emit(e)
+	})
+}
+
+// followedKey identifies a log line, to recognize it when read twice.
+type followedKey struct {
+	time, deliveryID, id, msg string
+}
+
+func followedKeyOf(e logger.LogEntry) followedKey {
+	return followedKey{time: e.Time, deliveryID: e.DeliveryID, id: e.ID, msg: e.Msg}
+}
+
+func printFollowedEntry(e logger.LogEntry) {
+	if e.Msg == logger.MsgReceived {
+		style.LogEntryLine(e.ParsedTime(), true, 0, e.Event, e.ID, e.Profile)
+		return
+	}
+
+	detail := fmt.Sprintf("%dms %s", e.DurationMs, e.URL)
+	if e.Error != "" {
+		detail += " " + e.Error
+	}
+	style.LogEntryLine(e.ParsedTime(), false, e.StatusCode, e.Event, e.ID, detail)
+}
+
+func printNoLogs(cmd *cobra.Command) error {
+	if hasLogFilters(cmd) {
+		fmt.Println("No log entries match the filters.")
+		return nil
+	}
+
+	logPath, _ := logger.GetLogFilePath()
+	fmt.Printf("No transaction logs found.\n")
+	fmt.Printf("Hint: Run 'abacatepay listen' to start recording webhook events.\n")
+	fmt.Printf("Log file location: %s\n", logPath)
+	return nil
+}
+
+func printDeliveriesTable(deliveries []logger.LoggedDelivery) {
+	var rows [][]string
+
+	for i := len(deliveries) - 1; i >= 0; i-- {
+		d := deliveries[i]
+
+		received := d.Time().Local().Format(time.DateTime)
+		if d.Resent() {
+			received += " (resend)"
+		}
+
+		latency := "-"
+		if d.Status != logger.StatusPending {
+			latency = fmt.Sprintf("%dms", d.DurationMs)
+		}
+
+		rows = append(rows, []string{
+			received,
+			d.Event,
+			d.EventID,
+			deliveryStatus(d),
+			latency,
+			d.URL,
+		})
+	}
+
+	style.PrintTable([]string{"Received", "Event", "ID", "Status", "Latency", "Target"}, rows)
+}
+
+func deliveryStatus(d logger.LoggedDelivery) string {
+	if d.StatusCode > 0 {
+		return fmt.Sprintf("%s [%d]", d.Status, d.StatusCode)
+	}
+	return d.Status
+}
+
+// validateEnvFlag checks an --env value against the environments events are
+// tagged with, so a typo doesn't silently match nothing.
+func validateEnvFlag(env string) error {
+	if env != "" && !slices.Contains(config.Environments, env) {
+		return fmt.Errorf("invalid environment %q (valid: %s)", env, strings.Join(config.Environments, ", "))
+	}
+	return nil
+}
+
+func logsQuery() (logger.Query, error) {
+	if err := validateEnvFlag(logsEnv); err != nil {
+		return logger.Query{}, err
+	}
+
+	query := logger.Query{
+		Profile:     logsProfile,
+		Env:         logsEnv,
+		Events:      logsEvents,
+		ResourceID:  logsResource,
+		URL:         logsURL,
+		MinDuration: logsMinDuration,
+		Search:      logsSearch,
+	}
+
+	if logsStatusCodes != "" {
+		codes, err := logger.ParseStatusRange(logsStatusCodes)
+		if err != nil {
+			return query, err
+		}
+		query.StatusCodes = codes
+	}
+
+	for _, where := range logsWhere {
+		cond, err := logger.ParseCondition(where)
+		if err != nil {
+			return query, err
+		}
+		query.Where = append(query.Where, cond)
+	}
+
+	return query, nil
+}
+
+func hasLogFilters(cmd *cobra.Command) bool {
+	for _, name := range []string{"type", "profile", "env", "event", "resource", "status-code", "since", "until", "url", "min-duration", "search", "where"} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
 	}
-	return printLogsTable(entries)
+	return false
 }
 
 func printLogsJSON(entries []logger.LogEntry) error {
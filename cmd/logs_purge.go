@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"abacatepay-cli/internal/logger"
+	"abacatepay-cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	purgeBefore  string
+	purgeProfile string
+	purgeAll     bool
+	purgeDryRun  bool
+)
+
+var logsPurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Delete entries from the local transaction logs",
+	Long: `Delete entries from transactions.log, its rotated backups and the event store.
+
+Without flags, entries older than the retention period set with 'logs settings'
+are removed. --before and --profile select entries by date and by the profile
+they were logged under; --all removes everything.`,
+	Example: `  abacatepay logs purge
+  abacatepay logs purge --before 7d
+  abacatepay logs purge --profile staging --dry-run
+  abacatepay logs purge --all`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return purgeLogs(cmd)
+	},
+}
+
+func init() {
+	logsPurgeCmd.Flags().StringVar(&purgeBefore, "before", "", "Delete entries older than a duration (7d, 12h) or a date")
+	logsPurgeCmd.Flags().StringVar(&purgeProfile, "profile", "", "Delete only entries logged under this profile")
+	logsPurgeCmd.Flags().BoolVar(&purgeAll, "all", false, "Delete every entry")
+	logsPurgeCmd.Flags().BoolVar(&purgeDryRun, "dry-run", false, "Count the entries that would be deleted without deleting them")
+
+	logsCmd.AddCommand(logsPurgeCmd)
+}
+
+func purgeLogs(cmd *cobra.Command) error {
+	opts := logger.PurgeOptions{Profile: purgeProfile, DryRun: purgeDryRun}
+
+	switch {
+	case purgeAll:
+		if purgeBefore != "" || purgeProfile != "" {
+			return fmt.Errorf("--all can't be combined with --before or --profile")
+		}
+	case purgeBefore != "":
+		before, err := parseTimeFlag("--before", purgeBefore, time.Now())
+		if err != nil {
+			return err
+		}
+		opts.Before = before
+	case purgeProfile == "":
+		settings, err := logger.LoadSettings()
+		if err != nil {
+			return err
+		}
+		opts.Before = settings.RetentionCutoff(time.Now())
+		if opts.Before.IsZero() {
+			return fmt.Errorf("retention is disabled, pass --before, --profile or --all to select what to delete")
+		}
+	}
+
+	result, err := logger.Purge(opts)
+	if err != nil {
+		return err
+	}
+
+	title := "Logs purged"
+	if purgeDryRun {
+		title = "Logs purge (dry run)"
+	}
+
+	fields := map[string]string{
+		"Removed": fmt.Sprintf("%d", result.Removed),
+		"Kept":    fmt.Sprintf("%d", result.Kept),
+		"Files":   fmt.Sprintf("%d", result.Files),
+	}
+	if !opts.Before.IsZero() {
+		fields["Before"] = opts.Before.Local().Format(time.DateTime)
+	}
+	if opts.Profile != "" {
+		fields["Profile"] = opts.Profile
+	}
+
+	output.Print(output.Result{Title: title, Fields: fields, Data: result})
+	return nil
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"abacatepay-cli/internal/logger"
+	"abacatepay-cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	settingsRetention    int
+	settingsRedact       bool
+	settingsRedactFields []string
+	settingsSeparate     bool
+)
+
+var logsSettingsCmd = &cobra.Command{
+	Use:   "settings",
+	Short: "Show or change how long transaction logs are kept and what they store",
+	Long: `Show or change the transaction log settings.
+
+--retention-days sets how long entries are kept (0 keeps them forever). Older
+entries are dropped from the event store and the rotated backups as new events
+are logged, and 'logs purge' removes them from the current log file.
+
+--redact masks the payload fields in --redact-fields (by default taxId, email
+and cellphone) before anything is written to disk. Entries logged before
+redaction was turned on are not changed, use 'logs purge' to remove them.
+Redacted events can't be resent as logged: 'events resend' refuses them unless
+the masked fields are set again with --set, --from-file or --edit.
+
+--separate-profiles writes the events of each profile to its own file under
+profiles/<name>/ in the log directory. Every command still reads them all,
+use --profile to tell them apart.`,
+	Example: `  abacatepay logs settings
+  abacatepay logs settings --retention-days 7 --redact
+  abacatepay logs settings --redact-fields taxId,email,cellphone,name`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return logsSettings(cmd)
+	},
+}
+
+func init() {
+	logsSettingsCmd.Flags().IntVar(&settingsRetention, "retention-days", 0, "Days to keep log entries, 0 keeps them forever")
+	logsSettingsCmd.Flags().BoolVar(&settingsRedact, "redact", false, "Mask PII fields in payloads before writing them")
+	logsSettingsCmd.Flags().StringSliceVar(&settingsRedactFields, "redact-fields", nil, "Payload fields masked by --redact")
+	logsSettingsCmd.Flags().BoolVar(&settingsSeparate, "separate-profiles", false, "Write the events of each profile to a separate log file")
+
+	logsCmd.AddCommand(logsSettingsCmd)
+}
+
+func logsSettings(cmd *cobra.Command) error {
+	settings, err := logger.LoadSettings()
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("retention-days") {
+		if settingsRetention < 0 {
+			return fmt.Errorf("--retention-days can't be negative")
+		}
+		settings.RetentionDays = settingsRetention
+	}
+	if flags.Changed("redact") {
+		settings.Redact = settingsRedact
+	}
+	if flags.Changed("redact-fields") {
+		settings.RedactFields = settingsRedactFields
+	}
+	if flags.Changed("separate-profiles") {
+		settings.SeparateProfiles = settingsSeparate
+	}
+
+	title := "Log settings"
+	if flags.Changed("retention-days") || flags.Changed("redact") || flags.Changed("redact-fields") || flags.Changed("separate-profiles") {
+		if err := logger.SaveSettings(settings); err != nil {
+			return err
+		}
+		title = "Log settings updated"
+	}
+
+	retention := "forever"
+	if settings.RetentionDays > 0 {
+		retention = fmt.Sprintf("%d days", settings.RetentionDays)
+	}
+
+	redact := "off"
+	if settings.Redact {
+		redact = "on"
+	}
+
+	separate := "off"
+	if settings.SeparateProfiles {
+		separate = "on"
+	}
+
+	path, _ := logger.GetSettingsPath()
+
+	output.Print(output.Result{
+		Title: title,
+		Fields: map[string]string{
+			"Retention":     retention,
+			"Redaction":     redact,
+			"Redact fields": strings.Join(settings.RedactFields, ", "),
+			"Per-profile":   separate,
+			"File":          path,
+		},
+		Data: settings,
+	})
+	return nil
+}
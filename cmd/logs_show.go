@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"encoding/json"
+
+	"abacatepay-cli/internal/logger"
+	"abacatepay-cli/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var logsShowCurrent bool
+
+var logsShowCmd = &cobra.Command{
+	Use:   "show <event-id|delivery-id>",
+	Short: "Show the payload and delivery timeline of a logged event",
+	Long: `Display a logged event: its payload and every delivery, or a single delivery
+when given a delivery ID. Each forward attempt shows the target, the signature
+header that was sent, the status, latency and response body.
+
+Use -o json for the full detail or -o table for one row per forward attempt.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return showLogs(args[0])
+	},
+}
+
+func init() {
+	logsShowCmd.Flags().BoolVar(&logsShowCurrent, "current-only", false, "Only read the current log file, ignoring rotated backups")
+
+	logsCmd.AddCommand(logsShowCmd)
+}
+
+func showLogs(id string) error {
+	deliveries, err := logger.FindDeliveries(id, logger.ReadOptions{CurrentOnly: logsShowCurrent})
+	if err != nil {
+		return err
+	}
+
+	output.PrintEventLog(eventLog(deliveries))
+	return nil
+}
+
+func eventLog(deliveries []logger.LoggedDelivery) output.EventLog {
+	latest := deliveries[len(deliveries)-1]
+
+	e := output.EventLog{
+		ID:     latest.EventID,
+		Event:  latest.Event,
+		Status: deliveryStatus(latest),
+	}
+
+	for _, d := range deliveries {
+		dl := output.DeliveryLog{ID: d.ID, ReceivedAt: d.ReceivedAt, Attempts: []output.ForwardAttempt{}}
+
+		for _, entry := range d.Entries {
+			if entry.Msg == logger.MsgReceived {
+				if entry.RawMessage != "" {
+					e.Payload = json.RawMessage(entry.RawMessage)
+				}
+				continue
+			}
+
+			dl.Attempts = append(dl.Attempts, output.ForwardAttempt{
+				Time:       entry.ParsedTime(),
+				URL:        entry.URL,
+				StatusCode: entry.StatusCode,
+				DurationMs: entry.DurationMs,
+				Signature:  entry.Signature,
+				Response:   entry.ResponseBody,
+				Error:      entry.Error,
+			})
+		}
+
+		e.Deliveries = append(e.Deliveries, dl)
+	}
+
+	if !json.Valid(e.Payload) {
+		e.Payload = nil
+	}
+	return e
+}
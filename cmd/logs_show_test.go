@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"testing"
+
+	"abacatepay-cli/internal/logger"
+)
+
+func TestEventLog(t *testing.T) {
+	received := logger.LogEntry{ID: "evt_1", DeliveryID: "dlv_1", Event: "billing.paid", Msg: logger.MsgReceived, Time: "2026-01-01T10:00:00Z", RawMessage: `{"v":1}`}
+	failed := logger.LogEntry{ID: "evt_1", DeliveryID: "dlv_1", Event: "billing.paid", Msg: logger.MsgForwardError, Time: "2026-01-01T10:00:01Z", URL: "http://localhost:3000", StatusCode: 500, DurationMs: 12, Signature: "sha256=abc", ResponseBody: "boom"}
+	resent := logger.LogEntry{ID: "evt_1", DeliveryID: "dlv_2", Event: "billing.paid", Msg: logger.MsgForwarded, Time: "2026-01-01T11:00:00Z", URL: "http://localhost:3000", StatusCode: 200, DurationMs: 8}
+
+	deliveries := logger.GroupDeliveries([]logger.LogEntry{received, failed, resent})
+	if len(deliveries) != 2 {
+		t.Fatalf("expected 2 deliveries, got %+v", deliveries)
+	}
+
+	e := eventLog(deliveries)
+
+	if e.ID != "evt_1" || e.Event != "billing.paid" || string(e.Payload) != `{"v":1}` {
+		t.Fatalf("unexpected event %+v", e)
+	}
+	if e.Status != deliveryStatus(deliveries[1]) {
+		t.Errorf("expected the status of the latest delivery, got %q", e.Status)
+	}
+	if len(e.Deliveries) != 2 {
+		t.Fatalf("expected 2 deliveries, got %+v", e.Deliveries)
+	}
+
+	first := e.Deliveries[0]
+	if first.ID != "dlv_1" || first.ReceivedAt.IsZero() || len(first.Attempts) != 1 {
+		t.Fatalf("unexpected first delivery %+v", first)
+	}
+	if a := first.Attempts[0]; a.StatusCode != 500 || a.DurationMs != 12 || a.Signature != "sha256=abc" || a.Response != "boom" || a.URL != "http://localhost:3000" {
+		t.Errorf("unexpected attempt %+v", a)
+	}
+
+	second := e.Deliveries[1]
+	if second.ID != "dlv_2" || !second.ReceivedAt.IsZero() || len(second.Attempts) != 1 || second.Attempts[0].StatusCode != 200 {
+		t.Errorf("expected a resend forwarded once, got %+v", second)
+	}
+}
+
+func TestEventLog_DropsInvalidPayload(t *testing.T) {
+	received := logger.LogEntry{ID: "evt_1", DeliveryID: "dlv_1", Event: "billing.paid", Msg: logger.MsgReceived, Time: "2026-01-01T10:00:00Z", RawMessage: `{"v":`}
+
+	e := eventLog(logger.GroupDeliveries([]logger.LogEntry{received}))
+	if e.Payload != nil {
+		t.Errorf("expected an invalid payload to be dropped, got %s", e.Payload)
+	}
+	if len(e.Deliveries) != 1 || len(e.Deliveries[0].Attempts) != 0 {
+		t.Errorf("expected one delivery without attempts, got %+v", e.Deliveries)
+	}
+}
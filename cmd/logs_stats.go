@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"time"
+
+	"abacatepay-cli/internal/config"
+	"abacatepay-cli/internal/logger"
+	"abacatepay-cli/internal/output"
+	"abacatepay-cli/internal/stats"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsSince   string
+	statsUntil   string
+	statsEvents  []string
+	statsProfile string
+	statsEnv     string
+	statsTop     int
+	statsCurrent bool
+)
+
+var logsStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize deliveries, failure rates and latency from the local log",
+	Long: `Summarize the webhook deliveries recorded locally over a period of time:
+
+  - deliveries per event type, and how many were delivered, failed or are pending
+  - forwards per target, with success and failure rates and latency p50/p95/p99
+  - the slowest forwards and the events that failed the most
+
+Latency is the duration of each forward to your local handler, so comparing two
+periods shows whether a change to the handler slowed it down.`,
+	Example: `  abacatepay logs stats
+  abacatepay logs stats --since 1h --event billing.paid
+  abacatepay logs stats --since 2026-01-01 --until 2026-01-02 -o json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return logsStats()
+	},
+}
+
+func init() {
+	logsStatsCmd.Flags().StringVar(&statsSince, "since", "24h", "Only entries since a duration ago (1h, 2d) or a date")
+	logsStatsCmd.Flags().StringVar(&statsUntil, "until", "", "Only entries until a duration ago (1h, 2d) or a date")
+	logsStatsCmd.Flags().StringSliceVar(&statsEvents, "event", nil, "Only these event types, can be repeated")
+	logsStatsCmd.Flags().StringVar(&statsProfile, "profile", "", "Only entries logged under this profile")
+	logsStatsCmd.Flags().StringVar(&statsEnv, "env", "", "Only entries of an environment ("+config.EnvProduction+", "+config.EnvSandbox+", "+config.EnvOffline+")")
+	logsStatsCmd.Flags().IntVar(&statsTop, "top", 5, "Number of slowest and most failing events to show")
+	logsStatsCmd.Flags().BoolVar(&statsCurrent, "current-only", false, "Only read the current log file, ignoring rotated backups")
+
+	logsCmd.AddCommand(logsStatsCmd)
+}
+
+func logsStats() error {
+	if err := validateEnvFlag(statsEnv); err != nil {
+		return err
+	}
+
+	opts := logger.ReadOptions{CurrentOnly: statsCurrent}
+
+	now := time.Now()
+	if statsSince != "" {
+		since, err := parseTimeFlag("--since", statsSince, now)
+		if err != nil {
+			return err
+		}
+		opts.Since = since
+	}
+	if statsUntil != "" {
+		until, err := parseTimeFlag("--until", statsUntil, now)
+		if err != nil {
+			return err
+		}
+		opts.Until = until
+	}
+
+	query := logger.Query{Profile: statsProfile, Env: statsEnv, Events: statsEvents}
+	deliveries, err := logger.SearchDeliveries(query, opts)
+	if err != nil {
+		return err
+	}
+
+	s := logger.SummarizeDeliveries(deliveries, statsTop)
+
+	result := output.LogStats{
+		Since:       opts.Since,
+		Until:       opts.Until,
+		Deliveries:  s.Deliveries,
+		Attempts:    s.Attempts,
+		Succeeded:   s.Succeeded,
+		Failed:      s.Failed,
+		SuccessRate: s.SuccessRate(),
+		LatencyMs:   latencyMs(s.Latency),
+		Events:      []output.EventCount{},
+		Targets:     []output.TargetStats{},
+		Slowest:     []output.SlowForward{},
+		MostFailing: []output.FailingEvent{},
+	}
+
+	for _, e := range s.Events {
+		result.Events = append(result.Events, output.EventCount{
+			Event:      e.Event,
+			Deliveries: e.Deliveries,
+			Delivered:  e.Delivered,
+			Failed:     e.Failed,
+			Pending:    e.Pending,
+		})
+	}
+
+	for _, t := range s.Targets {
+		result.Targets = append(result.Targets, output.TargetStats{
+			URL:         t.URL,
+			Attempts:    t.Attempts,
+			Succeeded:   t.Succeeded,
+			Failed:      t.Failed,
+			SuccessRate: t.SuccessRate(),
+			LatencyMs:   latencyMs(t.Latency),
+		})
+	}
+
+	for _, f := range s.Slowest {
+		result.Slowest = append(result.Slowest, output.SlowForward{
+			ID:         f.EventID,
+			Event:      f.Event,
+			URL:        f.URL,
+			Time:       f.Time,
+			StatusCode: f.StatusCode,
+			DurationMs: msFloat(f.Duration),
+		})
+	}
+
+	for _, f := range s.MostFailing {
+		result.MostFailing = append(result.MostFailing, output.FailingEvent{
+			ID:        f.EventID,
+			Event:     f.Event,
+			Attempts:  f.Attempts,
+			Failures:  f.Failures,
+			LastError: f.LastError,
+		})
+	}
+
+	output.PrintLogStats(result)
+	return nil
+}
+
+func latencyMs(l stats.Latency) output.LatencyMs {
+	return output.LatencyMs{
+		P50: msFloat(l.P50),
+		P95: msFloat(l.P95),
+		P99: msFloat(l.P99),
+		Max: msFloat(l.Max),
+	}
+}
@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var mockCmd = &cobra.Command{
+	Use:   "mock",
+	Short: "Generate realistic test data",
+}
+
+func init() {
+	rootCmd.AddCommand(mockCmd)
+}
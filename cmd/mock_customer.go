@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+
+	"abacatepay-cli/internal/mock"
+	"abacatepay-cli/internal/output"
+	"abacatepay-cli/internal/style"
+	"abacatepay-cli/internal/types"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	mockCustomerCount     int
+	mockCustomerType      string
+	mockCustomerFormatted bool
+)
+
+var mockCustomerCmd = &cobra.Command{
+	Use:   "customer",
+	Short: "Print realistic Brazilian customers with valid documents",
+	Long: `Print realistic Brazilian customers for testing.
+
+Every CPF and CNPJ passes the official checksum, cellphones use a real area code
+(DDD) and addresses use a CEP from the same city as the phone number.`,
+	Example: `  abacatepay mock customer
+  abacatepay mock customer -n 10 --type cnpj --formatted
+  abacatepay mock customer --seed 42 -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return mockCustomers()
+	},
+}
+
+func init() {
+	mockCustomerCmd.Flags().IntVarP(&mockCustomerCount, "count", "n", 1, "Number of customers to generate")
+	mockCustomerCmd.Flags().StringVar(&mockCustomerType, "type", "cpf", "Tax ID type: cpf, cnpj or random")
+	mockCustomerCmd.Flags().BoolVar(&mockCustomerFormatted, "formatted", false, "Format documents, phones and CEPs with punctuation")
+
+	mockCmd.AddCommand(mockCustomerCmd)
+}
+
+func mockCustomers() error {
+	if mockCustomerCount < 1 {
+		return fmt.Errorf("--count must be at least 1")
+	}
+
+	opts := mock.CustomerOptions{Formatted: mockCustomerFormatted}
+	if mockCustomerType != "random" {
+		opts.TaxIDType = mockCustomerType
+	}
+
+	customers := make([]*types.CustomerRecord, 0, mockCustomerCount)
+	for range mockCustomerCount {
+		customer, err := mock.Customer(opts)
+		if err != nil {
+			return err
+		}
+		customers = append(customers, customer)
+	}
+
+	if output.GetFormat() == output.FormatJSON {
+		style.PrintJSON(map[string]any{
+			"customers": customers,
+			"count":     len(customers),
+		})
+		return nil
+	}
+
+	rows := make([][]string, 0, len(customers))
+	for _, c := range customers {
+		rows = append(rows, []string{
+			c.Name,
+			c.TaxID,
+			c.Email,
+			c.Cellphone,
+			fmt.Sprintf("%s, %s - %s", c.Address.Street, c.Address.Number, c.Address.Neighborhood),
+			fmt.Sprintf("%s/%s", c.Address.City, c.Address.State),
+			c.Address.ZipCode,
+		})
+	}
+
+	style.PrintTable([]string{"Name", "Tax ID", "Email", "Cellphone", "Address", "City", "CEP"}, rows)
+
+	return nil
+}
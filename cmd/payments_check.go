@@ -21,6 +21,7 @@ func init() {
 
 func check(paymentID string) error {
 	return payments.ExecutePaymentAction(Local, Verbose, func(s *payments.Service) error {
-		return s.CheckPixQRCode(paymentID)
+		_, err := s.CheckPixQRCode(paymentID, false)
+		return err
 	})
 }
@@ -79,7 +79,8 @@ func createPayment(method string) error {
 	case "checkout":
 		if !createInteractive {
 			body := mock.CreateCheckoutMock()
-			return service.CreateCheckout(body)
+			_, err := service.CreateCheckout(body, false)
+			return err
 		}
 
 		body := &types.CreateCheckoutRequest{
@@ -88,7 +89,8 @@ func createPayment(method string) error {
 		if err := prompts.PromptForCheckout(body); err != nil {
 			return fmt.Errorf("failed to prompt checkout data: %w", err)
 		}
-		return service.CreateCheckout(body)
+		_, err := service.CreateCheckout(body, false)
+		return err
 
 	default:
 		return fmt.Errorf("invalid payment method: %s. Use 'pix' or 'checkout'", method)
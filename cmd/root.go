@@ -2,11 +2,16 @@
 package cmd
 
 import (
+	"fmt"
 	"log/slog"
 	"os"
+	"slices"
+	"strings"
 
 	"abacatepay-cli/internal/logger"
+	"abacatepay-cli/internal/mock"
 	"abacatepay-cli/internal/output"
+	"abacatepay-cli/internal/style"
 	"abacatepay-cli/internal/version"
 
 	"github.com/spf13/cobra"
@@ -23,12 +28,19 @@ var rootCmd = &cobra.Command{
 var (
 	Local, Verbose bool
 	OutputFormat   string
+	Seed           int64
+
+	LogFormat, LogFile, LogLevel string
 )
 
 func Exec() {
 	rootCmd.PersistentFlags().BoolVarP(&Verbose, "verbose", "v", false, "Enable verbose logging")
 	rootCmd.PersistentFlags().BoolVarP(&Local, "local", "l", false, "Use test server")
 	rootCmd.PersistentFlags().StringVarP(&OutputFormat, "output", "o", "text", "Output format: text, json, table")
+	rootCmd.PersistentFlags().Int64Var(&Seed, "seed", 0, "Seed for mock data generation (the same seed always yields the same payloads)")
+	rootCmd.PersistentFlags().StringVar(&LogFormat, "log-format", logger.FormatText, "Console log format: text, json, logfmt")
+	rootCmd.PersistentFlags().StringVar(&LogFile, "log-file", "", "Write the CLI log to this file (default ~/.abacatepay/logs/abacatepay.log)")
+	rootCmd.PersistentFlags().StringVar(&LogLevel, "log-level", "", "Log level, overridable per subsystem ("+strings.Join(logger.Subsystems, ", ")+"), e.g. info,ws=debug,payments=warn")
 
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
 		format, err := output.ParseFormat(OutputFormat)
@@ -36,23 +48,45 @@ func Exec() {
 			return err
 		}
 		output.SetFormat(format)
+		if format == output.FormatJSON {
+			style.SetLogOutput(os.Stderr)
+		}
+
+		if cmd.Flags().Changed("seed") {
+			mock.Seed(Seed)
+		}
 
 		level := slog.LevelInfo
 		if Verbose {
 			level = slog.LevelDebug
 		}
 
+		level, levels, err := logger.ParseLevels(LogLevel, level)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(logger.ConsoleFormats, LogFormat) {
+			return fmt.Errorf("invalid log format %q (valid: %s)", LogFormat, strings.Join(logger.ConsoleFormats, ", "))
+		}
+
 		cfg, err := logger.DefaultConfig()
 		if err != nil {
-			h := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})
-			slog.SetDefault(slog.New(h))
-			return nil
+			cfg = &logger.Config{}
 		}
 
 		cfg.Level = level
+		cfg.Levels = levels
+		cfg.Format = LogFormat
+		cfg.File = LogFile
+
+		// Without a home directory or a writable log file, log to stderr only.
+		if cfg.LogDir == "" && cfg.File == "" {
+			_, err = logger.SetupConsole(cfg)
+			return err
+		}
 		if _, err := logger.Setup(cfg); err != nil {
-			h := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})
-			slog.SetDefault(slog.New(h))
+			_, err = logger.SetupConsole(cfg)
+			return err
 		}
 		return nil
 	}
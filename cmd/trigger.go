@@ -1,35 +1,141 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"abacatepay-cli/internal/mock"
 	"abacatepay-cli/internal/output"
 	"abacatepay-cli/internal/payments"
+	"abacatepay-cli/internal/style"
 	"abacatepay-cli/internal/utils"
+	"abacatepay-cli/internal/webhook"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	triggerOffline       bool
+	triggerForwardURL    string
+	triggerSecret        string
+	triggerOverrides     []string
+	triggerOverridesFile string
+	triggerScenario      string
+	triggerCount         int
+	triggerRate          string
+	triggerConcurrency   int
+	triggerWait          bool
+	triggerTimeout       time.Duration
+)
+
 var triggerCmd = &cobra.Command{
-	Use:   "trigger <event>",
-	Args:  cobra.ExactArgs(1),
-	Short: "Trigger test events",
+	Use:       "trigger <event>",
+	Args:      triggerArgs,
+	ValidArgs: mock.EventNames(),
+	Short:     "Trigger test events",
+	Long: `Trigger test events.
+
+billing.paid creates a real PIX QR code and simulates its payment through the API,
+so the webhook is delivered to your 'listen' session. Every other event in the
+catalog (see 'abacatepay events list') is generated locally as a mock.
+
+With --offline the event is generated, signed, written to the local transaction
+log and delivered straight to --forward-to, without authentication or network
+access to AbacatePay. The command fails if the app doesn't respond with a 2xx.
+--set and --from-file change the generated payload, so billing.paid needs
+--offline to use them. With -o json the delivery lines go to stderr.
+
+With --scenario a YAML file describes a multi-step flow (create a charge, simulate
+the payment, wait for the webhook, assert its status...) and values produced by
+one step can be used by the next ones as {{ .name.field }}. See
+examples/scenarios for complete flows, including refunds and expired charges.
+
+With --count and --offline the event is delivered many times to --forward-to,
+optionally throttled by --rate, and a report with throughput, forward latency
+percentiles and error counts is printed. The command fails if any delivery
+errored or the app responded with a non-2xx.
+
+With --wait the command blocks until the webhook has been received over the
+WebSocket and forwarded to --forward-to, and exits with a non-zero status if it
+doesn't arrive within --timeout or the app doesn't respond with a 2xx. --timeout
+covers the whole command, including the API calls.`,
+	Example: `  abacatepay trigger billing.paid
+  abacatepay trigger subscription.created --offline --forward-to http://localhost:3000/webhooks
+  abacatepay trigger billing.paid --offline --set data.billing.amount=5000
+  abacatepay trigger --scenario checkout-happy-path.yaml
+  abacatepay trigger billing.paid --offline --count 500 --rate 50/s
+  abacatepay trigger billing.paid --wait --timeout 60s --forward-to http://localhost:3000/webhooks`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if triggerScenario != "" {
+			return runScenario(triggerScenario)
+		}
+		if triggerCount < 1 {
+			return fmt.Errorf("--count must be at least 1")
+		}
+		if triggerWait && triggerOffline {
+			return fmt.Errorf("--wait can't be combined with --offline, offline deliveries already fail on a non-2xx")
+		}
+		if triggerCount > 1 {
+			if triggerWait {
+				return fmt.Errorf("--wait can't be combined with --count")
+			}
+			return runLoad(args[0])
+		}
 		return trigger(args[0])
 	},
 }
 
 func init() {
+	triggerCmd.Flags().BoolVar(&triggerOffline, "offline", false, "Generate and deliver the event locally, without calling the API")
+	triggerCmd.Flags().StringVar(&triggerForwardURL, "forward-to", "", "URL to deliver the event to (with --offline or --wait)")
+	triggerCmd.Flags().StringVar(&triggerSecret, "secret", localSigningSecret, "Webhook signing secret (with --offline)")
+	triggerCmd.Flags().StringArrayVar(&triggerOverrides, "set", nil, "Override a payload field (path=value), can be repeated")
+	triggerCmd.Flags().StringVar(&triggerOverridesFile, "from-file", "", "JSON file with field overrides")
+	triggerCmd.Flags().StringVar(&triggerScenario, "scenario", "", "Run a YAML scenario file instead of a single event")
+	triggerCmd.Flags().IntVar(&triggerCount, "count", 1, "Number of events to send")
+	triggerCmd.Flags().StringVar(&triggerRate, "rate", "", "Maximum send rate with --count, e.g. 50/s, 600/m (default: unthrottled)")
+	triggerCmd.Flags().IntVar(&triggerConcurrency, "concurrency", 10, "Maximum in-flight deliveries with --count")
+	triggerCmd.Flags().BoolVar(&triggerWait, "wait", false, "Wait for the webhook to be delivered and fail if it isn't, or if the app responds non-2xx")
+	triggerCmd.Flags().DurationVar(&triggerTimeout, "timeout", 30*time.Second, "Deadline for the whole command with --wait, API calls included")
+
 	rootCmd.AddCommand(triggerCmd)
 }
 
+func triggerArgs(cmd *cobra.Command, args []string) error {
+	if triggerScenario != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("an event can't be passed together with --scenario")
+		}
+		return nil
+	}
+	return cobra.ExactArgs(1)(cmd, args)
+}
+
 func trigger(evt string) error {
+	if triggerOffline {
+		return triggerOfflineEvent(evt)
+	}
+
+	// billing.paid is created by the API, so there is no local payload to change.
+	if evt == "billing.paid" && (len(triggerOverrides) > 0 || triggerOverridesFile != "") {
+		return fmt.Errorf("--set and --from-file can't change a billing.paid created through the API, use --offline to deliver a modified event")
+	}
+
 	deps, err := utils.SetupClient(Local, Verbose)
 	if err != nil {
 		return err
 	}
 
+	if triggerWait {
+		return triggerAndWait(deps, evt)
+	}
+
 	return handleEvent(deps, evt)
 }
 
@@ -64,26 +170,118 @@ func handleEvent(deps *utils.Dependencies, evt string) error {
 
 		return nil
 
-	case "payout.done", "payout.failed":
-		isDone := evt == "payout.done"
-		mockEvent := mock.MockPayoutEvent(isDone)
+	default:
+		message, header, err := encodeTriggerEvent(evt)
+		if err != nil {
+			return err
+		}
+
+		txLogger, err := utils.SetupTransactionLogger(deps.LogTags())
+		if err != nil {
+			return fmt.Errorf("failed to initialize transaction logger: %w", err)
+		}
+
+		listener := webhook.NewListener(deps.Config, deps.Client, "", deps.Config.TokenKey, txLogger)
+		if err := listener.Record(message); err != nil {
+			return err
+		}
 
 		output.Print(output.Result{
 			Title: fmt.Sprintf("Mock %s Triggered", evt),
 			Fields: map[string]string{
-				"Event ID":       mockEvent.ID,
-				"Transaction ID": mockEvent.Data.Transaction.ID,
-				"Status":         mockEvent.Data.Transaction.Status,
+				"Event ID": header.ID,
+				"Event":    header.Event,
 			},
-			Data: mockEvent,
+			Data: json.RawMessage(message),
 		})
 
-		fmt.Printf("\nTip: Use 'abacatepay events resend %s' to send this mock to your local server.\n", mockEvent.ID)
+		fmt.Printf("\nTip: Use 'abacatepay events resend %s' to send this mock to your local server.\n", header.ID)
 
-		// NOTE: We could automatically append this mock to the local log file, so it appears in 'logs list' immediately.
 		return nil
+	}
+}
 
-	default:
-		return fmt.Errorf("invalid event type: %s. Supported: billing.paid, payout.done, payout.failed", evt)
+func triggerOfflineEvent(evt string) error {
+	deps := utils.SetupDependencies(Local, Verbose)
+
+	url, err := utils.GetForwardURL(triggerForwardURL, utils.DefaultForwardURL)
+	if err != nil {
+		return err
+	}
+
+	message, header, err := encodeTriggerEvent(evt)
+	if err != nil {
+		return err
+	}
+
+	txLogger, err := utils.SetupTransactionLogger(deps.LogTags())
+	if err != nil {
+		return fmt.Errorf("failed to initialize transaction logger: %w", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	listener := webhook.NewListener(deps.Config, deps.Client, url, deps.Config.TokenKey, txLogger)
+	listener.SetSigningSecret(triggerSecret)
+
+	style.LogSigningSecret(triggerSecret)
+
+	delivery, err := listener.Deliver(ctx, message)
+	if err != nil {
+		return err
+	}
+	if delivery.Err != nil {
+		return delivery.Err
 	}
+
+	title := fmt.Sprintf("%s Delivered", evt)
+	if !delivery.OK() {
+		title = fmt.Sprintf("%s Rejected", evt)
+	}
+
+	output.Print(output.Result{
+		Title: title,
+		Fields: map[string]string{
+			"Event ID": header.ID,
+			"URL":      url,
+			"Status":   fmt.Sprintf("%d %s", delivery.StatusCode, http.StatusText(delivery.StatusCode)),
+			"Duration": fmt.Sprintf("%dms", delivery.Duration.Milliseconds()),
+		},
+		Data: map[string]any{
+			"event":      evt,
+			"id":         header.ID,
+			"url":        url,
+			"statusCode": delivery.StatusCode,
+			"durationMs": delivery.Duration.Milliseconds(),
+		},
+	})
+
+	if !delivery.OK() {
+		return fmt.Errorf("%s was delivered to %s but the app responded with status %d", evt, url, delivery.StatusCode)
+	}
+	return nil
+}
+
+type eventHeader struct {
+	ID    string `json:"id"`
+	Event string `json:"event"`
+}
+
+func encodeTriggerEvent(evt string) ([]byte, eventHeader, error) {
+	var header eventHeader
+
+	data, err := generateEvent(evt, triggerOverrides, triggerOverridesFile)
+	if err != nil {
+		return nil, header, err
+	}
+
+	message, err := json.Marshal(data)
+	if err != nil {
+		return nil, header, fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	_ = json.Unmarshal(message, &header)
+
+	return message, header, nil
 }
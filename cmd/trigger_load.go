@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"abacatepay-cli/internal/output"
+	"abacatepay-cli/internal/stats"
+	"abacatepay-cli/internal/utils"
+	"abacatepay-cli/internal/webhook"
+
+	"golang.org/x/sync/errgroup"
+)
+
+type loadResult struct {
+	sent       bool
+	statusCode int
+	duration   time.Duration
+	err        error
+}
+
+// runLoad delivers the same event type triggerCount times to the local app, at
+// most triggerRate per interval, and reports throughput and forward latency. It
+// fails if any delivery errored or got a non-2xx response.
+func runLoad(evt string) error {
+	// Through the API the forwards happen in a 'listen' session, out of reach of
+	// this command, so there would be nothing to measure.
+	if !triggerOffline {
+		return fmt.Errorf("--count needs --offline, load tests deliver the events straight to --forward-to")
+	}
+
+	if triggerConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	interval, err := parseRate(triggerRate)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	send, target, err := loadSender(evt)
+	if err != nil {
+		return err
+	}
+
+	if output.GetFormat() != output.FormatJSON {
+		fmt.Printf("Sending %d %s events to %s...\n\n", triggerCount, evt, target)
+	}
+
+	results := make([]loadResult, triggerCount)
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(triggerConcurrency)
+
+	var ticker *time.Ticker
+	if interval > 0 {
+		ticker = time.NewTicker(interval)
+		defer ticker.Stop()
+	}
+
+	start := time.Now()
+
+loop:
+	for i := range triggerCount {
+		if ticker != nil && i > 0 {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				break loop
+			}
+		}
+
+		g.Go(func() error {
+			results[i] = send(gCtx, i)
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+	elapsed := time.Since(start)
+
+	if unsuccessful := printLoadReport(evt, target, results, elapsed); unsuccessful > 0 {
+		return fmt.Errorf("%d of %d %s events weren't delivered successfully", unsuccessful, triggerCount, evt)
+	}
+	return nil
+}
+
+func loadSender(evt string) (func(ctx context.Context, i int) loadResult, string, error) {
+	deps := utils.SetupDependencies(Local, Verbose)
+
+	url, err := utils.GetForwardURL(triggerForwardURL, utils.DefaultForwardURL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Payloads are generated up front so --seed stays deterministic regardless
+	// of the order in which concurrent deliveries run.
+	messages := make([][]byte, triggerCount)
+	for i := range messages {
+		messages[i], _, err = encodeTriggerEvent(evt)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	txLogger, err := utils.SetupTransactionLogger(deps.LogTags())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to initialize transaction logger: %w", err)
+	}
+
+	listener := webhook.NewListener(deps.Config, deps.Client, url, deps.Config.TokenKey, txLogger)
+	listener.SetSigningSecret(triggerSecret)
+
+	return func(ctx context.Context, i int) loadResult {
+		d, err := listener.Deliver(ctx, messages[i])
+		if err != nil {
+			return loadResult{sent: true, err: err}
+		}
+		return loadResult{sent: true, statusCode: d.StatusCode, duration: d.Duration, err: d.Err}
+	}, url, nil
+}
+
+// parseRate accepts "50/s", "100/m", "3600/h" or a bare number of events per second.
+func parseRate(rate string) (time.Duration, error) {
+	if rate == "" {
+		return 0, nil
+	}
+
+	countStr, unit, hasUnit := strings.Cut(rate, "/")
+	count, err := strconv.ParseFloat(countStr, 64)
+	if err != nil || count <= 0 {
+		return 0, fmt.Errorf("invalid rate %q. Expected something like 50/s", rate)
+	}
+
+	per := time.Second
+	if hasUnit {
+		switch unit {
+		case "s":
+			per = time.Second
+		case "m":
+			per = time.Minute
+		case "h":
+			per = time.Hour
+		default:
+			return 0, fmt.Errorf("invalid rate unit %q (valid: s, m, h)", unit)
+		}
+	}
+
+	return time.Duration(float64(per) / count), nil
+}
+
+// printLoadReport prints the load test report and returns the number of events
+// that errored or got a non-2xx response.
+func printLoadReport(evt, target string, results []loadResult, elapsed time.Duration) int {
+	var (
+		sent, succeeded, failed, errored int
+		latencies                        []time.Duration
+	)
+	statusCodes := map[string]int{}
+
+	for _, r := range results {
+		if !r.sent {
+			continue
+		}
+		sent++
+
+		if r.err != nil {
+			errored++
+			continue
+		}
+
+		latencies = append(latencies, r.duration)
+
+		statusCodes[strconv.Itoa(r.statusCode)]++
+		if r.statusCode >= 200 && r.statusCode < 300 {
+			succeeded++
+		} else {
+			failed++
+		}
+	}
+
+	latency := stats.Summarize(latencies)
+	throughput := float64(sent) / elapsed.Seconds()
+
+	codes := make([]string, 0, len(statusCodes))
+	for code, n := range statusCodes {
+		codes = append(codes, fmt.Sprintf("%s×%d", code, n))
+	}
+	sort.Strings(codes)
+
+	fields := map[string]string{
+		"Event":       evt,
+		"Target":      target,
+		"Sent":        fmt.Sprintf("%d/%d", sent, len(results)),
+		"Succeeded":   strconv.Itoa(succeeded),
+		"Failed":      strconv.Itoa(failed),
+		"Errors":      strconv.Itoa(errored),
+		"Throughput":  fmt.Sprintf("%.1f events/s", throughput),
+		"Latency p50": formatLatency(latency.P50),
+		"Latency p95": formatLatency(latency.P95),
+		"Latency p99": formatLatency(latency.P99),
+		"Latency max": formatLatency(latency.Max),
+		"Elapsed":     elapsed.Round(time.Millisecond).String(),
+	}
+	if len(codes) > 0 {
+		fields["Status"] = strings.Join(codes, " ")
+	}
+
+	output.Print(output.Result{
+		Title:  "Load Test Finished",
+		Fields: fields,
+		Data: map[string]any{
+			"event":       evt,
+			"target":      target,
+			"requested":   len(results),
+			"sent":        sent,
+			"succeeded":   succeeded,
+			"failed":      failed,
+			"errors":      errored,
+			"statusCodes": statusCodes,
+			"throughput":  throughput,
+			"elapsedMs":   elapsed.Milliseconds(),
+			"latencyMs": map[string]float64{
+				"min":  msFloat(latency.Min),
+				"mean": msFloat(latency.Mean),
+				"p50":  msFloat(latency.P50),
+				"p95":  msFloat(latency.P95),
+				"p99":  msFloat(latency.P99),
+				"max":  msFloat(latency.Max),
+			},
+		},
+	})
+
+	return failed + errored
+}
+
+func formatLatency(d time.Duration) string {
+	return fmt.Sprintf("%.1fms", msFloat(d))
+}
+
+func msFloat(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
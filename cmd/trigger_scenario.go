@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"abacatepay-cli/internal/output"
+	"abacatepay-cli/internal/payments"
+	"abacatepay-cli/internal/scenario"
+	"abacatepay-cli/internal/utils"
+)
+
+func runScenario(path string) error {
+	s, err := scenario.Load(path)
+	if err != nil {
+		return err
+	}
+
+	var deps *utils.Dependencies
+	if s.RequiresAuth() {
+		deps, err = utils.SetupClient(Local, Verbose)
+		if err != nil {
+			return err
+		}
+	} else {
+		deps = utils.SetupDependencies(Local, Verbose)
+	}
+
+	txLogger, err := utils.SetupTransactionLogger(deps.LogTags())
+	if err != nil {
+		return fmt.Errorf("failed to initialize transaction logger: %w", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	runner := &scenario.Runner{
+		Service:  payments.New(deps.Client, deps.Config.APIBaseURL, Verbose),
+		Config:   deps.Config,
+		Client:   deps.Client,
+		TxLogger: txLogger,
+		Secret:   triggerSecret,
+	}
+
+	name := s.Name
+	if name == "" {
+		name = path
+	}
+
+	if output.GetFormat() != output.FormatJSON {
+		fmt.Printf("Running scenario %s (%d steps)\n\n", name, len(s.Steps))
+	}
+
+	results, runErr := runner.Run(ctx, s)
+
+	steps := make([]map[string]any, 0, len(results))
+	for _, r := range results {
+		step := map[string]any{
+			"step":       r.Title,
+			"action":     r.Action,
+			"durationMs": r.Duration.Milliseconds(),
+			"output":     r.Output,
+		}
+		if r.Err != nil {
+			step["error"] = r.Err.Error()
+		}
+		steps = append(steps, step)
+	}
+
+	status := "Passed"
+	if runErr != nil {
+		status = "Failed"
+	}
+
+	output.Print(output.Result{
+		Title: fmt.Sprintf("Scenario %s", status),
+		Fields: map[string]string{
+			"Scenario": name,
+			"Steps":    fmt.Sprintf("%d/%d", len(results), len(s.Steps)),
+			"Status":   status,
+		},
+		Data: map[string]any{
+			"scenario": name,
+			"status":   status,
+			"steps":    steps,
+		},
+	})
+
+	return runErr
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"abacatepay-cli/internal/mock"
+	"abacatepay-cli/internal/output"
+	"abacatepay-cli/internal/payments"
+	"abacatepay-cli/internal/utils"
+	"abacatepay-cli/internal/webhook"
+)
+
+// triggerAndWait triggers billing.paid through the API and blocks until the
+// resulting webhook arrives over the WebSocket and has been forwarded. Any
+// outcome other than a 2xx from the local app is returned as an error, so the
+// command can be used as a CI step.
+func triggerAndWait(deps *utils.Dependencies, evt string) error {
+	if evt != "billing.paid" {
+		return fmt.Errorf("--wait is only supported for billing.paid, use --offline to deliver %s directly", evt)
+	}
+
+	url, err := utils.GetForwardURL(triggerForwardURL, utils.DefaultForwardURL)
+	if err != nil {
+		return err
+	}
+
+	txLogger, err := utils.SetupTransactionLogger(deps.LogTags())
+	if err != nil {
+		return fmt.Errorf("failed to initialize transaction logger: %w", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	// --timeout bounds the whole command: connecting, the API calls and the wait
+	// for the webhook share a single deadline.
+	ctx, cancelTimeout := context.WithTimeout(ctx, triggerTimeout)
+	defer cancelTimeout()
+
+	listener := webhook.NewListener(deps.Config, deps.Client, url, deps.Config.TokenKey, txLogger)
+	waiter := webhook.StartWaiter(ctx, listener)
+
+	if err := waiter.Ready(ctx, triggerTimeout); err != nil {
+		return waitTimeoutErr(err)
+	}
+
+	service := payments.New(deps.Client, deps.Config.APIBaseURL, Verbose).WithContext(ctx)
+
+	pixID, err := service.CreatePixQRCode(mock.CreatePixQRCodeMock(), true)
+	if err != nil {
+		return waitTimeoutErr(err)
+	}
+
+	if err := service.SimulatePixQRCodePayment(pixID, true); err != nil {
+		return waitTimeoutErr(err)
+	}
+
+	received, err := waiter.Wait(ctx, triggerTimeout, func(r webhook.Received) bool {
+		return r.Delivery.Event == evt && bytes.Contains(r.Message, []byte(pixID))
+	})
+	if err != nil {
+		return fmt.Errorf("%s for charge %s: %w", evt, pixID, waitTimeoutErr(err))
+	}
+
+	d := received.Delivery
+	if d.Err != nil {
+		return fmt.Errorf("failed to forward %s to %s: %w", evt, url, d.Err)
+	}
+	if !d.OK() {
+		return fmt.Errorf("%s was forwarded to %s but the app responded with status %d", evt, url, d.StatusCode)
+	}
+
+	output.Print(output.Result{
+		Title: fmt.Sprintf("%s Delivered", evt),
+		Fields: map[string]string{
+			"Charge ID": pixID,
+			"Event ID":  d.ID,
+			"URL":       url,
+			"Status":    fmt.Sprintf("%d %s", d.StatusCode, http.StatusText(d.StatusCode)),
+			"Duration":  fmt.Sprintf("%dms", d.Duration.Milliseconds()),
+		},
+		Data: map[string]any{
+			"event":      evt,
+			"chargeId":   pixID,
+			"id":         d.ID,
+			"url":        url,
+			"statusCode": d.StatusCode,
+			"durationMs": d.Duration.Milliseconds(),
+		},
+	})
+
+	return nil
+}
+
+// waitTimeoutErr reports a --timeout expiry in terms of the flag rather than as
+// a bare context error.
+func waitTimeoutErr(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s (--timeout): %w", triggerTimeout, err)
+	}
+	return err
+}
@@ -3,7 +3,6 @@ package auth
 import (
 	"context"
 	"fmt"
-	"log/slog"
 	"net/http"
 	"os"
 	"time"
@@ -11,6 +10,7 @@ import (
 	"github.com/go-resty/resty/v2"
 
 	"abacatepay-cli/internal/config"
+	"abacatepay-cli/internal/logger"
 	"abacatepay-cli/internal/store"
 	"abacatepay-cli/internal/style"
 
@@ -100,7 +100,7 @@ func loginWithDeviceFlow(params *LoginParams) error {
 func saveAndActivateProfile(st store.TokenStore, profile, token string) error {
 	existingToken, _ := st.GetNamed(profile)
 	if existingToken != "" {
-		slog.Info("Updating existing profile", "name", profile)
+		logger.Subsystem("auth").Info("Updating existing profile", "name", profile)
 	}
 
 	if err := st.SaveNamed(profile, token); err != nil {
@@ -120,7 +120,7 @@ func tryOpenBrowser(params *LoginParams, verificationURI string) bool {
 	}
 
 	if err := params.OpenBrowser(verificationURI); err != nil {
-		slog.Debug("Unable to open browser automatically", "error", err)
+		logger.Subsystem("auth").Debug("Unable to open browser automatically", "error", err)
 
 		return false
 	}
@@ -156,12 +156,12 @@ func Logout(st store.TokenStore) (string, error) {
 	if len(profiles) > 0 {
 		_ = st.SetActiveProfile(profiles[0])
 
-		slog.Info("Signed out", "profile", activeProfile, "switched_to", profiles[0])
+		logger.Subsystem("auth").Info("Signed out", "profile", activeProfile, "switched_to", profiles[0])
 		return activeProfile, nil
 	}
 
 	_ = st.SetActiveProfile("")
-	slog.Info("Signed out", "profile", activeProfile)
+	logger.Subsystem("auth").Info("Signed out", "profile", activeProfile)
 
 	return activeProfile, nil
 }
@@ -189,7 +189,7 @@ func pollForToken(ctx context.Context, cfg *config.Config, client *resty.Client,
 			SetResult(&result).
 			Post(cfg.APIBaseURL + "/token")
 		if err != nil {
-			slog.Debug("Token request failed", "error", err)
+			logger.Subsystem("auth").Debug("Token request failed", "error", err)
 
 			continue
 		}
@@ -206,10 +206,10 @@ func pollForToken(ctx context.Context, cfg *config.Config, client *resty.Client,
 		case http.StatusUnauthorized:
 			return "", fmt.Errorf("authorization denied")
 		case http.StatusInternalServerError:
-			slog.Warn("Server error, retrying...")
+			logger.Subsystem("auth").Warn("Server error, retrying...")
 			continue
 		default:
-			slog.Debug("Unexpected response", "status", resp.StatusCode())
+			logger.Subsystem("auth").Debug("Unexpected response", "status", resp.StatusCode())
 		}
 	}
 
@@ -0,0 +1,168 @@
+package codegen
+
+import (
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var funcs = template.FuncMap{
+	"tsType": tsType,
+	"goType": goType,
+	"goName": goName,
+	"goTag":  goTag,
+	"pyType": pyType,
+	"snake":  snake,
+	"pascal": Pascal,
+	"quote":  strconv.Quote,
+	"needsTime": func(types []Type) bool {
+		for _, t := range types {
+			for _, f := range t.Fields {
+				if usesKind(f.Ref, KindDateTime) {
+					return true
+				}
+			}
+		}
+		return false
+	},
+}
+
+func usesKind(r Ref, kind string) bool {
+	if r.Kind == kind {
+		return true
+	}
+	return r.Elem != nil && usesKind(*r.Elem, kind)
+}
+
+func tsType(f Field) string {
+	t := tsRef(f.Ref)
+	if f.Nullable {
+		t += " | null"
+	}
+	return t
+}
+
+func tsRef(r Ref) string {
+	if r.Const != "" {
+		return strconv.Quote(r.Const)
+	}
+
+	switch r.Kind {
+	case KindString, KindDateTime:
+		return "string"
+	case KindInteger, KindNumber:
+		return "number"
+	case KindBoolean:
+		return "boolean"
+	case KindObject:
+		return r.Name
+	case KindArray:
+		return tsRef(*r.Elem) + "[]"
+	default:
+		return "unknown"
+	}
+}
+
+func goType(f Field) string {
+	t := goRef(f.Ref)
+	if (f.Optional || f.Nullable) && (f.Ref.Kind == KindObject || f.Ref.Kind == KindDateTime) {
+		t = "*" + t
+	}
+	return t
+}
+
+func goRef(r Ref) string {
+	switch r.Kind {
+	case KindString:
+		return "string"
+	case KindDateTime:
+		return "time.Time"
+	case KindInteger:
+		return "int64"
+	case KindNumber:
+		return "float64"
+	case KindBoolean:
+		return "bool"
+	case KindObject:
+		return r.Name
+	case KindArray:
+		return "[]" + goRef(*r.Elem)
+	default:
+		return "any"
+	}
+}
+
+var goInitialisms = map[string]string{"Id": "ID", "Url": "URL", "Api": "API", "Pix": "PIX"}
+
+// goName converts a JSON name to an exported Go identifier, keeping common
+// initialisms upper-case: "externalId" becomes "ExternalID".
+func goName(jsonName string) string {
+	name := Pascal(jsonName)
+	for from, to := range goInitialisms {
+		if strings.HasSuffix(name, from) {
+			name = strings.TrimSuffix(name, from) + to
+		}
+	}
+	return name
+}
+
+func goTag(f Field) string {
+	tag := f.JSON
+	if f.Optional {
+		tag += ",omitempty"
+	}
+	return "`json:" + strconv.Quote(tag) + "`"
+}
+
+func pyType(f Field) string {
+	t := pyRef(f.Ref)
+	if f.Nullable {
+		t = "Optional[" + t + "]"
+	}
+	if f.Optional {
+		t = "NotRequired[" + t + "]"
+	}
+	return t
+}
+
+func pyRef(r Ref) string {
+	if r.Const != "" {
+		return "Literal[" + strconv.Quote(r.Const) + "]"
+	}
+
+	switch r.Kind {
+	case KindString, KindDateTime:
+		return "str"
+	case KindInteger:
+		return "int"
+	case KindNumber:
+		return "float"
+	case KindBoolean:
+		return "bool"
+	case KindObject:
+		return r.Name
+	case KindArray:
+		return "list[" + pyRef(*r.Elem) + "]"
+	default:
+		return "Any"
+	}
+}
+
+// snake converts "billing.paid" or "onBillingPaid" to "billing_paid" / "on_billing_paid".
+func snake(s string) string {
+	var out strings.Builder
+	for i, r := range s {
+		switch {
+		case r == '.' || r == '-':
+			out.WriteRune('_')
+		case r >= 'A' && r <= 'Z':
+			if i > 0 {
+				out.WriteRune('_')
+			}
+			out.WriteRune(r + ('a' - 'A'))
+		default:
+			out.WriteRune(r)
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,172 @@
+package codegen
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+type Options struct {
+	Lang      string
+	Framework string
+	// Package is the Go package name of the generated files.
+	Package string
+}
+
+type File struct {
+	Path    string
+	Content []byte
+	// Stub files are meant to be edited: the handlers the user fills in. The
+	// others are regenerated as a whole whenever the schema changes.
+	Stub bool
+}
+
+type file struct {
+	template string
+	output   string
+	stub     bool
+}
+
+type stack struct {
+	frameworks map[string]file
+	common     []file
+}
+
+var stacks = map[string]stack{
+	"ts": {
+		common: []file{
+			{"ts/types.ts.tmpl", "types.ts", false},
+			{"ts/verify.ts.tmpl", "verify.ts", false},
+			{"ts/handlers.ts.tmpl", "handlers.ts", true},
+			{"ts/dispatch.ts.tmpl", "dispatch.ts", false},
+		},
+		frameworks: map[string]file{
+			"next":    {"ts/next.ts.tmpl", "route.ts", false},
+			"express": {"ts/express.ts.tmpl", "router.ts", false},
+			"elysia":  {"ts/elysia.ts.tmpl", "plugin.ts", false},
+		},
+	},
+	"go": {
+		common: []file{
+			{"go/events.go.tmpl", "events.go", false},
+			{"go/verify.go.tmpl", "verify.go", false},
+			{"go/handlers.go.tmpl", "handlers.go", true},
+		},
+		frameworks: map[string]file{
+			"net/http": {"go/router.go.tmpl", "router.go", false},
+		},
+	},
+	"python": {
+		common: []file{
+			{"python/init.py.tmpl", "__init__.py", false},
+			{"python/events.py.tmpl", "events.py", false},
+			{"python/verify.py.tmpl", "verify.py", false},
+			{"python/handlers.py.tmpl", "handlers.py", true},
+		},
+		frameworks: map[string]file{
+			"fastapi": {"python/fastapi.py.tmpl", "router.py", false},
+		},
+	},
+}
+
+var defaultFrameworks = map[string]string{
+	"ts":     "next",
+	"go":     "net/http",
+	"python": "fastapi",
+}
+
+func Languages() []string {
+	return sortedStackKeys(stacks)
+}
+
+func Frameworks(lang string) []string {
+	s, ok := stacks[lang]
+	if !ok {
+		return nil
+	}
+	return sortedStackKeys(s.frameworks)
+}
+
+func Generate(m Model, opts Options) ([]File, error) {
+	lang := opts.Lang
+	if lang == "typescript" {
+		lang = "ts"
+	}
+
+	s, ok := stacks[lang]
+	if !ok {
+		return nil, fmt.Errorf("unsupported language %q (valid: %s)", opts.Lang, strings.Join(Languages(), ", "))
+	}
+
+	framework := opts.Framework
+	if framework == "" {
+		framework = defaultFrameworks[lang]
+	}
+
+	router, ok := s.frameworks[framework]
+	if !ok {
+		return nil, fmt.Errorf("unsupported framework %q for %s (valid: %s)", framework, lang, strings.Join(Frameworks(lang), ", "))
+	}
+
+	pkg := opts.Package
+	if pkg == "" {
+		pkg = "abacatepay"
+	}
+
+	data := map[string]any{
+		"Events":    m.Events,
+		"Types":     m.Types,
+		"Package":   pkg,
+		"Framework": framework,
+		"Header":    "Code generated by abacatepay events codegen. DO NOT EDIT.",
+	}
+
+	files := make([]File, 0, len(s.common)+1)
+	for _, f := range append(s.common, router) {
+		content, err := render(f.template, data)
+		if err != nil {
+			return nil, err
+		}
+
+		if lang == "go" {
+			content, err = format.Source(content)
+			if err != nil {
+				return nil, fmt.Errorf("generated invalid Go code for %s: %w", f.output, err)
+			}
+		}
+
+		files = append(files, File{Path: f.output, Content: content, Stub: f.stub})
+	}
+
+	return files, nil
+}
+
+func render(name string, data any) ([]byte, error) {
+	tmpl, err := template.New(path.Base(name)).Funcs(funcs).ParseFS(templatesFS, "templates/"+name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+func sortedStackKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,76 @@
+package codegen
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"abacatepay-cli/internal/mock"
+	"abacatepay-cli/internal/schema"
+)
+
+func TestGenerate_EveryStack(t *testing.T) {
+	var inputs []Input
+	for _, def := range mock.Events() {
+		inputs = append(inputs, Input{
+			Name:        def.Name,
+			Description: def.Description,
+			Schema:      schema.ForEvent(def.Name, def.Description, def.Generate()),
+		})
+	}
+	m := Build(inputs)
+
+	for _, lang := range Languages() {
+		for _, framework := range Frameworks(lang) {
+			files, err := Generate(m, Options{Lang: lang, Framework: framework})
+			if err != nil {
+				t.Fatalf("%s/%s: unexpected error: %v", lang, framework, err)
+			}
+
+			for _, f := range files {
+				if bytes.Contains(f.Content, []byte("<no value>")) {
+					t.Errorf("%s/%s: %s has unresolved template values", lang, framework, f.Path)
+				}
+			}
+		}
+	}
+}
+
+func TestBuild_SharesObjectTypes(t *testing.T) {
+	m := Build([]Input{
+		{Name: "billing.created", Schema: schema.ForEvent("billing.created", "", mock.MockBillingCreatedEvent())},
+		{Name: "billing.paid", Schema: schema.ForEvent("billing.paid", "", mock.MockBillingPaidEvent())},
+	})
+
+	count := 0
+	for _, typ := range m.Types {
+		if typ.Name == "Billing" {
+			count++
+		}
+		if typ.Name == "Billing2" {
+			t.Fatalf("expected the billing object to be shared, got %s", typ.Name)
+		}
+	}
+	if count != 1 {
+		t.Fatalf("expected one Billing type, got %d", count)
+	}
+}
+
+func TestGenerate_OnlyHandlersAreStubs(t *testing.T) {
+	for _, lang := range Languages() {
+		files, err := Generate(Build(nil), Options{Lang: lang})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", lang, err)
+		}
+
+		var stubs []string
+		for _, f := range files {
+			if f.Stub {
+				stubs = append(stubs, f.Path)
+			}
+		}
+		if len(stubs) != 1 || !strings.HasPrefix(stubs[0], "handlers.") {
+			t.Errorf("%s: expected only the handlers file to be a stub, got %v", lang, stubs)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// Package codegen turns the event schemas into typed webhook handler code
+// (type definitions, a signature verifier and an event router) for a few stacks.
+package codegen
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"abacatepay-cli/internal/schema"
+)
+
+type Input struct {
+	Name        string
+	Description string
+	Schema      *schema.Schema
+}
+
+type Model struct {
+	Events []Event
+	// Types are ordered so every type comes after the types it references.
+	Types []Type
+}
+
+type Event struct {
+	Name        string
+	Description string
+	Type        string
+	Handler     string
+}
+
+type Type struct {
+	Name   string
+	Fields []Field
+}
+
+type Field struct {
+	JSON     string
+	Ref      Ref
+	Optional bool
+	Nullable bool
+}
+
+// Ref is the type of a field: a scalar kind, a named object type, or an array of Elem.
+type Ref struct {
+	Kind  string
+	Name  string
+	Const string
+	Elem  *Ref
+}
+
+const (
+	KindString   = "string"
+	KindDateTime = "datetime"
+	KindInteger  = "integer"
+	KindNumber   = "number"
+	KindBoolean  = "boolean"
+	KindObject   = "object"
+	KindArray    = "array"
+	KindAny      = "any"
+)
+
+func Build(inputs []Input) Model {
+	b := &builder{byShape: map[string]string{}, names: map[string]bool{}}
+
+	var m Model
+	for _, in := range inputs {
+		typeName := Pascal(in.Name) + "Event"
+		b.object(typeName, in.Schema, false)
+
+		m.Events = append(m.Events, Event{
+			Name:        in.Name,
+			Description: in.Description,
+			Type:        typeName,
+			Handler:     "on" + Pascal(in.Name),
+		})
+	}
+
+	m.Types = b.types
+	return m
+}
+
+type builder struct {
+	types   []Type
+	byShape map[string]string
+	names   map[string]bool
+}
+
+// object registers the type of an object schema and returns its name. Shared
+// shapes such as the billing or customer objects are emitted once; an event's
+// own "data" object always gets a type named after the event.
+func (b *builder) object(name string, s *schema.Schema, dedupe bool) string {
+	shape := ""
+	if dedupe {
+		data, _ := json.Marshal(s)
+		shape = string(data)
+		if existing, ok := b.byShape[shape]; ok {
+			return existing
+		}
+	}
+
+	name = b.uniqueName(name)
+
+	t := Type{Name: name}
+	required := map[string]bool{}
+	for _, r := range s.Required {
+		required[r] = true
+	}
+
+	keys := make([]string, 0, len(s.Properties))
+	for k := range s.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		prop := s.Properties[key]
+
+		childName := Pascal(key)
+		if key == "data" {
+			childName = strings.TrimSuffix(name, "Event") + "Data"
+		}
+
+		t.Fields = append(t.Fields, Field{
+			JSON:     key,
+			Ref:      b.ref(childName, prop, key != "data"),
+			Optional: !required[key],
+			Nullable: isNullable(prop),
+		})
+	}
+
+	b.types = append(b.types, t)
+	if dedupe {
+		b.byShape[shape] = name
+	}
+	return name
+}
+
+func (b *builder) ref(name string, s *schema.Schema, dedupe bool) Ref {
+	ref := Ref{Kind: kindOf(s)}
+	if c, ok := s.Const.(string); ok {
+		ref.Const = c
+	}
+
+	switch ref.Kind {
+	case KindObject:
+		if s.Properties == nil {
+			ref.Kind = KindAny
+			break
+		}
+		ref.Name = b.object(name, s, dedupe)
+	case KindArray:
+		elem := Ref{Kind: KindAny}
+		if s.Items != nil {
+			elem = b.ref(name+"Item", s.Items, true)
+		}
+		ref.Elem = &elem
+	}
+
+	return ref
+}
+
+func (b *builder) uniqueName(name string) string {
+	candidate := name
+	for i := 2; b.names[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	b.names[candidate] = true
+	return candidate
+}
+
+func kindOf(s *schema.Schema) string {
+	for _, t := range typesOf(s) {
+		switch t {
+		case "null":
+			continue
+		case "string":
+			if s.Format == "date-time" {
+				return KindDateTime
+			}
+			return KindString
+		case "integer", "number", "boolean", "object", "array":
+			return t
+		}
+	}
+	return KindAny
+}
+
+func isNullable(s *schema.Schema) bool {
+	for _, t := range typesOf(s) {
+		if t == "null" {
+			return true
+		}
+	}
+	return false
+}
+
+func typesOf(s *schema.Schema) []string {
+	switch t := s.Type.(type) {
+	case string:
+		return []string{t}
+	case []string:
+		return t
+	default:
+		return nil
+	}
+}
+
+// Pascal converts names like "billing.paid" or "externalId" to "BillingPaid"
+// and "ExternalId".
+func Pascal(s string) string {
+	var out strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		out.WriteRune(r)
+	}
+	return out.String()
+}
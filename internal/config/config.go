@@ -2,7 +2,20 @@ package config
 
 import "time"
 
+const (
+	EnvProduction = "production"
+	EnvSandbox    = "sandbox"
+	// EnvOffline tags events generated locally, which never reached the API.
+	EnvOffline = "offline"
+)
+
+// Environments are the values events can be tagged with.
+var Environments = []string{EnvProduction, EnvSandbox, EnvOffline}
+
 type Config struct {
+	// Environment is EnvProduction or EnvSandbox, used to tag logged events
+	// that went through the API.
+	Environment       string
 	Verbose           bool
 	APIBaseURL        string
 	WebSocketBaseURL  string
@@ -14,6 +27,7 @@ type Config struct {
 
 func Default() *Config {
 	return &Config{
+		Environment:       EnvProduction,
 		APIBaseURL:        "https://api.abacatepay.com",
 		WebSocketBaseURL:  "wss://ws.abacatepay.com/ws",
 		ServiceName:       "abacatepay-cli",
@@ -26,6 +40,7 @@ func Default() *Config {
 
 func Local() *Config {
 	cfg := Default()
+	cfg.Environment = EnvSandbox
 	cfg.APIBaseURL = "http://191.252.202.128:8080"
 	cfg.WebSocketBaseURL = "ws://191.252.202.128:8080/ws"
 	return cfg
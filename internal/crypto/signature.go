@@ -7,9 +7,15 @@ import (
 	"fmt"
 )
 
+const SignatureHeader = "X-Abacate-Signature"
+
 func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
 	payload := fmt.Sprintf("%d.%s", timestamp, string(body))
 	h := hmac.New(sha256.New, []byte(secret))
 	h.Write([]byte(payload))
 	return hex.EncodeToString(h.Sum(nil))
 }
+
+func FormatSignatureHeader(timestamp int64, signature string) string {
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, signature)
+}
@@ -0,0 +1,213 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// LoggedDelivery is one attempt at delivering an event: the received line, if
+// any, and the outcome of forwarding it. Resends have no received line.
+type LoggedDelivery struct {
+	ID          string     `json:"deliveryId,omitempty"`
+	EventID     string     `json:"id"`
+	Event       string     `json:"event"`
+	Profile     string     `json:"profile,omitempty"`
+	Env         string     `json:"env,omitempty"`
+	ReceivedAt  time.Time  `json:"receivedAt,omitzero"`
+	ForwardedAt time.Time  `json:"forwardedAt,omitzero"`
+	URL         string     `json:"url,omitempty"`
+	Status      string     `json:"status"`
+	StatusCode  int        `json:"statusCode,omitempty"`
+	DurationMs  int64      `json:"durationMs,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	Entries     []LogEntry `json:"-"`
+}
+
+// Time is when the delivery started.
+func (d LoggedDelivery) Time() time.Time {
+	if !d.ReceivedAt.IsZero() {
+		return d.ReceivedAt
+	}
+	return d.ForwardedAt
+}
+
+func (d LoggedDelivery) Resent() bool {
+	return d.ReceivedAt.IsZero()
+}
+
+// GroupDeliveries groups entries into deliveries, in the order they started.
+// Entries written before delivery IDs were logged are paired by event ID: a
+// forward belongs to the latest received line of its event not yet forwarded.
+func GroupDeliveries(entries []LogEntry) []LoggedDelivery {
+	var deliveries []*LoggedDelivery
+	byDeliveryID := map[string]*LoggedDelivery{}
+	openByEventID := map[string]*LoggedDelivery{}
+
+	for _, entry := range entries {
+		if entry.Msg != MsgReceived && !isForwardMsg(entry.Msg) {
+			continue
+		}
+
+		var d *LoggedDelivery
+		switch {
+		case entry.DeliveryID != "":
+			d = byDeliveryID[entry.DeliveryID]
+		case isForwardMsg(entry.Msg):
+			d = openByEventID[entry.ID]
+		}
+
+		if d == nil || (entry.Msg == MsgReceived && !d.ReceivedAt.IsZero()) {
+			d = &LoggedDelivery{
+				ID:      entry.DeliveryID,
+				EventID: entry.ID,
+				Event:   entry.Event,
+				Profile: entry.Profile,
+				Env:     entry.Env,
+				Status:  StatusPending,
+			}
+			deliveries = append(deliveries, d)
+			if entry.DeliveryID != "" {
+				byDeliveryID[entry.DeliveryID] = d
+			}
+		}
+		d.Entries = append(d.Entries, entry)
+
+		if entry.Msg == MsgReceived {
+			d.ReceivedAt = entry.ParsedTime()
+			if entry.DeliveryID == "" {
+				openByEventID[entry.ID] = d
+			}
+			continue
+		}
+
+		if openByEventID[entry.ID] == d {
+			delete(openByEventID, entry.ID)
+		}
+
+		d.ForwardedAt = entry.ParsedTime()
+		d.URL = entry.URL
+		d.StatusCode = entry.StatusCode
+		d.DurationMs = entry.DurationMs
+		d.Error = entry.Error
+		d.Status = StatusFailed
+		if entry.Msg == MsgForwarded {
+			d.Status = StatusDelivered
+		}
+	}
+
+	grouped := make([]LoggedDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		grouped = append(grouped, *d)
+	}
+	return grouped
+}
+
+func isForwardMsg(msg string) bool {
+	return msg == MsgForwarded || msg == MsgForwardError || msg == MsgForwardFailed
+}
+
+// entryKey identifies a log line within a read of the logs.
+type entryKey struct {
+	deliveryID, id, msg, time, timestamp string
+}
+
+func keyOf(e LogEntry) entryKey {
+	return entryKey{deliveryID: e.DeliveryID, id: e.ID, msg: e.Msg, time: e.Time, timestamp: e.Timestamp}
+}
+
+// SearchDeliveries returns the deliveries with at least one entry matching q
+// and opts. The limit applies to deliveries, keeping the most recent.
+func SearchDeliveries(q Query, opts ReadOptions) ([]LoggedDelivery, error) {
+	entries, err := deliveryEntries(q, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := map[entryKey]bool{}
+	for _, e := range q.Filter(entries) {
+		if opts.includes(e) {
+			matched[keyOf(e)] = true
+		}
+	}
+
+	var deliveries []LoggedDelivery
+	for _, d := range GroupDeliveries(entries) {
+		for _, e := range d.Entries {
+			if matched[keyOf(e)] {
+				deliveries = append(deliveries, d)
+				break
+			}
+		}
+	}
+
+	if opts.Limit > 0 && len(deliveries) > opts.Limit {
+		deliveries = deliveries[len(deliveries)-opts.Limit:]
+	}
+	return deliveries, nil
+}
+
+// deliveryEntries reads the entries SearchDeliveries groups. The store selects
+// the matching deliveries itself unless q filters on the payload, which needs
+// every entry of an event to be read.
+func deliveryEntries(q Query, opts ReadOptions) ([]LogEntry, error) {
+	if !opts.CurrentOnly && !q.filtersPayload() {
+		store, err := OpenStore()
+		if err == nil {
+			defer store.Close()
+			return store.DeliveryEntries(context.Background(), q, opts)
+		}
+		slog.Debug("event store unavailable, reading the log files", "error", err)
+	}
+
+	opts.Limit = 0
+	opts.TypeFilter = ""
+	return ReadTransactionLogs(opts)
+}
+
+// FindDeliveries returns every delivery of an event, or the single delivery
+// when id is a delivery ID.
+func FindDeliveries(id string, opts ReadOptions) ([]LoggedDelivery, error) {
+	entries, err := findEntries(id, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var deliveries []LoggedDelivery
+	for _, d := range GroupDeliveries(entries) {
+		if d.EventID == id || d.ID == id {
+			deliveries = append(deliveries, d)
+		}
+	}
+
+	if len(deliveries) == 0 {
+		return nil, fmt.Errorf("no event or delivery with ID %s found in local logs", id)
+	}
+	return deliveries, nil
+}
+
+func findEntries(id string, opts ReadOptions) ([]LogEntry, error) {
+	if !opts.CurrentOnly {
+		store, err := OpenStore()
+		if err == nil {
+			defer store.Close()
+			return store.EntriesFor(context.Background(), id)
+		}
+		slog.Debug("event store unavailable, reading the log files", "error", err)
+	}
+
+	files, err := transactionLogFiles(opts.CurrentOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []LogEntry
+	err = scanLogFiles(files, func(e LogEntry) {
+		if e.ID == id || e.DeliveryID == id {
+			entries = append(entries, e)
+		}
+	})
+	sortEntries(entries)
+	return entries, err
+}
@@ -0,0 +1,138 @@
+package logger
+
+import "time"
+
+const (
+	MsgReceived      = "webhook_received"
+	MsgForwarded     = "webhook_forwarded"
+	MsgForwardError  = "webhook_forward_error"
+	MsgForwardFailed = "webhook_forward_failed"
+)
+
+const (
+	StatusDelivered = "delivered"
+	StatusFailed    = "failed"
+	StatusPending   = "pending"
+)
+
+var EventStatuses = []string{StatusDelivered, StatusFailed, StatusPending}
+
+// LoggedEvent is a received event together with the outcome of its latest
+// forward attempt.
+type LoggedEvent struct {
+	ID         string    `json:"id"`
+	Event      string    `json:"event"`
+	Time       time.Time `json:"time"`
+	URL        string    `json:"url,omitempty"`
+	Status     string    `json:"status"`
+	StatusCode int       `json:"statusCode,omitempty"`
+	RawMessage string    `json:"-"`
+}
+
+type EventFilter struct {
+	Since  time.Time
+	Events []string
+	Status string
+
+	CurrentOnly bool
+}
+
+// ReadEvents returns the events in the transaction log that match the filter,
+// oldest first. An event received more than once is returned once, with the
+// payload of its first occurrence.
+func ReadEvents(filter EventFilter) ([]LoggedEvent, error) {
+	entries, err := ReadTransactionLogs(ReadOptions{Since: filter.Since, CurrentOnly: filter.CurrentOnly})
+	if err != nil {
+		return nil, err
+	}
+
+	return GroupEvents(entries, filter), nil
+}
+
+func GroupEvents(entries []LogEntry, filter EventFilter) []LoggedEvent {
+	var events []*LoggedEvent
+	byID := map[string]*LoggedEvent{}
+
+	for _, entry := range entries {
+		if entry.ID == "" {
+			continue
+		}
+
+		switch entry.Msg {
+		case MsgReceived:
+			if _, seen := byID[entry.ID]; seen || entry.RawMessage == "" {
+				continue
+			}
+
+			e := &LoggedEvent{
+				ID:         entry.ID,
+				Event:      entry.Event,
+				Time:       entry.ParsedTime(),
+				Status:     StatusPending,
+				RawMessage: entry.RawMessage,
+			}
+			byID[entry.ID] = e
+			events = append(events, e)
+
+		case MsgForwarded, MsgForwardError, MsgForwardFailed:
+			e, ok := byID[entry.ID]
+			if !ok {
+				continue
+			}
+
+			e.URL = entry.URL
+			e.StatusCode = entry.StatusCode
+			e.Status = StatusFailed
+			if entry.Msg == MsgForwarded {
+				e.Status = StatusDelivered
+			}
+		}
+	}
+
+	grouped := make([]LoggedEvent, 0, len(events))
+	for _, e := range events {
+		grouped = append(grouped, *e)
+	}
+	return FilterEvents(grouped, filter)
+}
+
+func FilterEvents(events []LoggedEvent, filter EventFilter) []LoggedEvent {
+	matched := make([]LoggedEvent, 0, len(events))
+	for _, e := range events {
+		if filter.matches(e) {
+			matched = append(matched, e)
+		}
+	}
+	return matched
+}
+
+func (f EventFilter) matches(e LoggedEvent) bool {
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+
+	if f.Status != "" && f.Status != "any" && e.Status != f.Status {
+		return false
+	}
+
+	if len(f.Events) == 0 {
+		return true
+	}
+	for _, name := range f.Events {
+		if e.Event == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsedTime is the time the entry was written, or the zero time if it can't
+// be parsed.
+func (e LogEntry) ParsedTime() time.Time {
+	for _, value := range []string{e.Time, e.Timestamp} {
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestGroupEvents_UsesLatestDelivery(t *testing.T) {
+	entries := []LogEntry{
+		{Msg: MsgReceived, ID: "evt_1", Event: "billing.paid", Time: "2026-01-01T10:00:00Z", RawMessage: `{"id":"evt_1"}`},
+		{Msg: MsgForwardError, ID: "evt_1", Event: "billing.paid", StatusCode: 500},
+		{Msg: MsgReceived, ID: "evt_2", Event: "payout.done", Time: "2026-01-01T11:00:00Z", RawMessage: `{"id":"evt_2"}`},
+		{Msg: MsgForwardError, ID: "evt_2", Event: "payout.done", StatusCode: 500},
+		{Msg: MsgForwarded, ID: "evt_2", Event: "payout.done", StatusCode: 200},
+		{Msg: MsgReceived, ID: "evt_1", Event: "billing.paid", Time: "2026-01-01T12:00:00Z", RawMessage: `{"id":"evt_1"}`},
+	}
+
+	events := GroupEvents(entries, EventFilter{})
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].ID != "evt_1" || events[0].Status != StatusFailed {
+		t.Fatalf("expected evt_1 to be failed, got %+v", events[0])
+	}
+	if events[1].Status != StatusDelivered {
+		t.Fatalf("expected evt_2 to be delivered, got %+v", events[1])
+	}
+
+	failed := GroupEvents(entries, EventFilter{Status: StatusFailed, Events: []string{"billing.paid"}})
+	if len(failed) != 1 || failed[0].ID != "evt_1" {
+		t.Fatalf("expected only evt_1, got %+v", failed)
+	}
+}
+
+func TestGroupDeliveries(t *testing.T) {
+	entries := []LogEntry{
+		{Msg: MsgReceived, ID: "evt_1", Time: "2026-01-01T10:00:00Z"},
+		{Msg: MsgForwardError, ID: "evt_1", StatusCode: 500, Time: "2026-01-01T10:00:01Z"},
+		{Msg: MsgForwarded, ID: "evt_1", StatusCode: 200, Time: "2026-01-01T10:05:00Z"},
+		{Msg: MsgReceived, ID: "evt_2", DeliveryID: "dlv_a", Time: "2026-01-01T11:00:00Z"},
+		{Msg: MsgReceived, ID: "evt_3", DeliveryID: "dlv_b", Time: "2026-01-01T11:00:01Z"},
+		{Msg: MsgForwarded, ID: "evt_2", DeliveryID: "dlv_a", StatusCode: 200, DurationMs: 12, Time: "2026-01-01T11:00:02Z"},
+	}
+
+	deliveries := GroupDeliveries(entries)
+	if len(deliveries) != 4 {
+		t.Fatalf("expected 4 deliveries, got %+v", deliveries)
+	}
+	if deliveries[0].Status != StatusFailed || deliveries[0].Resent() {
+		t.Fatalf("expected the first legacy delivery to have failed, got %+v", deliveries[0])
+	}
+	if !deliveries[1].Resent() || deliveries[1].Status != StatusDelivered {
+		t.Fatalf("expected a delivered resend, got %+v", deliveries[1])
+	}
+	if deliveries[2].ID != "dlv_a" || deliveries[2].DurationMs != 12 || len(deliveries[2].Entries) != 2 {
+		t.Fatalf("expected dlv_a with its forward, got %+v", deliveries[2])
+	}
+	if deliveries[3].Status != StatusPending {
+		t.Fatalf("expected dlv_b to be pending, got %+v", deliveries[3])
+	}
+}
+
+func TestSearchDeliveries_MatchesByEntry(t *testing.T) {
+	dir := setupProfileLogs(t)
+
+	lines := `{"time":"2026-01-01T10:00:00Z","msg":"webhook_received","id":"evt_1","delivery_id":"dlv_1","event":"billing.paid"}
+{"time":"2026-01-01T10:00:01Z","msg":"webhook_forward_error","id":"evt_1","delivery_id":"dlv_1","event":"billing.paid","status_code":500}
+{"time":"2026-01-01T11:00:00Z","msg":"webhook_received","id":"evt_1","delivery_id":"dlv_2","event":"billing.paid"}
+{"time":"2026-01-01T11:00:01Z","msg":"webhook_forwarded","id":"evt_1","delivery_id":"dlv_2","event":"billing.paid","status_code":200}
+{"time":"2026-01-01T12:00:00Z","msg":"webhook_received","id":"evt_2","delivery_id":"dlv_3","event":"billing.paid"}
+{"time":"2026-01-01T12:00:01Z","msg":"webhook_forward_error","id":"evt_2","delivery_id":"dlv_3","event":"billing.paid","status_code":502}
+`
+	writeFile(t, filepath.Join(dir, "transactions.log"), lines)
+
+	codes, err := ParseStatusRange("5xx")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := func(deliveries []LoggedDelivery) []string {
+		var ids []string
+		for _, d := range deliveries {
+			ids = append(ids, d.ID)
+		}
+		return ids
+	}
+
+	deliveries, err := SearchDeliveries(Query{StatusCodes: codes}, ReadOptions{CurrentOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(deliveries); !slices.Equal(got, []string{"dlv_1", "dlv_3"}) {
+		t.Fatalf("expected the failed deliveries, got %v", got)
+	}
+
+	deliveries, err = SearchDeliveries(Query{StatusCodes: codes}, ReadOptions{CurrentOnly: true, Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(deliveries); !slices.Equal(got, []string{"dlv_3"}) {
+		t.Fatalf("expected the latest failed delivery, got %v", got)
+	}
+
+	deliveries, err = SearchDeliveries(Query{}, ReadOptions{CurrentOnly: true, TypeFilter: MsgForwarded})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(deliveries); !slices.Equal(got, []string{"dlv_2"}) {
+		t.Fatalf("expected the delivered delivery, got %v", got)
+	}
+}
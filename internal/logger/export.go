@@ -0,0 +1,243 @@
+package logger
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"abacatepay-cli/internal/crypto"
+)
+
+var ExportFormats = []string{"har", "csv", "ndjson"}
+
+// Export writes entries to w in one of ExportFormats.
+func Export(w io.Writer, format string, entries []LogEntry, creatorVersion string) error {
+	switch format {
+	case "ndjson":
+		return ExportNDJSON(w, entries)
+	case "csv":
+		return ExportCSV(w, entries)
+	case "har":
+		return ExportHAR(w, entries, creatorVersion)
+	}
+	return fmt.Errorf("invalid export format %q (valid: %s)", format, strings.Join(ExportFormats, ", "))
+}
+
+// ExportNDJSON writes one JSON object per entry, as found in the log.
+func ExportNDJSON(w io.Writer, entries []LogEntry) error {
+	enc := json.NewEncoder(w)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("failed to write entry: %w", err)
+		}
+	}
+	return nil
+}
+
+var csvColumns = []string{
+	"time", "msg", "event", "id", "delivery_id", "url", "status_code",
+	"duration_ms", "size_bytes", "error", "signature", "response_body", "raw_message",
+}
+
+func ExportCSV(w io.Writer, entries []LogEntry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvColumns); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	for _, e := range entries {
+		record := []string{
+			e.ParsedTime().UTC().Format(time.RFC3339Nano),
+			e.Msg,
+			e.Event,
+			e.ID,
+			e.DeliveryID,
+			e.URL,
+			optionalInt(int64(e.StatusCode)),
+			optionalInt(e.DurationMs),
+			optionalInt(int64(e.SizeBytes)),
+			e.Error,
+			e.Signature,
+			e.ResponseBody,
+			e.RawMessage,
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func optionalInt(n int64) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.FormatInt(n, 10)
+}
+
+// HAR 1.2, see http://www.softwareishard.com/blog/har-12-spec/. Only the
+// fields the transaction log can fill are written.
+type harFile struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            int64       `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string       `json:"method"`
+	URL         string       `json:"url"`
+	HTTPVersion string       `json:"httpVersion"`
+	Cookies     []struct{}   `json:"cookies"`
+	Headers     []harHeader  `json:"headers"`
+	QueryString []struct{}   `json:"queryString"`
+	PostData    *harPostData `json:"postData,omitempty"`
+	HeadersSize int          `json:"headersSize"`
+	BodySize    int          `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int        `json:"status"`
+	StatusText  string     `json:"statusText"`
+	HTTPVersion string     `json:"httpVersion"`
+	Cookies     []struct{} `json:"cookies"`
+	Headers     []struct{} `json:"headers"`
+	Content     harContent `json:"content"`
+	RedirectURL string     `json:"redirectURL"`
+	HeadersSize int        `json:"headersSize"`
+	BodySize    int        `json:"bodySize"`
+	Error       string     `json:"_error,omitempty"`
+}
+
+type harHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Send    int64 `json:"send"`
+	Wait    int64 `json:"wait"`
+	Receive int64 `json:"receive"`
+}
+
+// ExportHAR writes every forward attempt in entries as a HAR request. The
+// request body is the payload of the event as it was received.
+func ExportHAR(w io.Writer, entries []LogEntry, creatorVersion string) error {
+	payloads := map[string]string{}
+	deliveryPayloads := map[string]string{}
+
+	file := harFile{Log: harLog{
+		Version: "1.2",
+		Creator: harCreator{Name: "abacatepay-cli", Version: creatorVersion},
+		Entries: []harEntry{},
+	}}
+
+	for _, e := range entries {
+		if e.Msg == MsgReceived {
+			payloads[e.ID] = e.RawMessage
+			if e.DeliveryID != "" {
+				deliveryPayloads[e.DeliveryID] = e.RawMessage
+			}
+			continue
+		}
+		if !isForwardMsg(e.Msg) {
+			continue
+		}
+
+		body, ok := deliveryPayloads[e.DeliveryID]
+		if !ok {
+			body = payloads[e.ID]
+		}
+
+		file.Log.Entries = append(file.Log.Entries, harEntryFor(e, body))
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(file); err != nil {
+		return fmt.Errorf("failed to write HAR: %w", err)
+	}
+	return nil
+}
+
+func harEntryFor(e LogEntry, body string) harEntry {
+	started := e.ParsedTime().Add(-time.Duration(e.DurationMs) * time.Millisecond)
+
+	headers := []harHeader{{Name: "Content-Type", Value: "application/json"}}
+	if e.Signature != "" {
+		headers = append(headers, harHeader{Name: crypto.SignatureHeader, Value: e.Signature})
+	}
+
+	entry := harEntry{
+		StartedDateTime: started.UTC().Format(time.RFC3339Nano),
+		Time:            e.DurationMs,
+		Request: harRequest{
+			Method:      http.MethodPost,
+			URL:         e.URL,
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []struct{}{},
+			Headers:     headers,
+			QueryString: []struct{}{},
+			HeadersSize: -1,
+			BodySize:    len(body),
+		},
+		Response: harResponse{
+			Status:      e.StatusCode,
+			StatusText:  http.StatusText(e.StatusCode),
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []struct{}{},
+			Headers:     []struct{}{},
+			Content: harContent{
+				Size:     len(e.ResponseBody),
+				MimeType: "application/octet-stream",
+				Text:     e.ResponseBody,
+			},
+			HeadersSize: -1,
+			BodySize:    -1,
+			Error:       e.Error,
+		},
+		Timings: harTimings{Wait: e.DurationMs},
+		Comment: strings.TrimSpace(e.Event + " " + e.ID),
+	}
+
+	if body != "" {
+		entry.Request.PostData = &harPostData{MimeType: "application/json", Text: body}
+	}
+	return entry
+}
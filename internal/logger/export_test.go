@@ -0,0 +1,63 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"abacatepay-cli/internal/crypto"
+)
+
+func TestExportHAR_UsesDeliveryPayload(t *testing.T) {
+	entries := []LogEntry{
+		{Msg: MsgReceived, ID: "evt_1", DeliveryID: "dlv_a", Time: "2026-01-01T10:00:00Z", RawMessage: `{"v":1}`},
+		{Msg: MsgForwardError, ID: "evt_1", DeliveryID: "dlv_a", Time: "2026-01-01T10:00:01Z", URL: "http://localhost/hook", StatusCode: 500, DurationMs: 20, Signature: "t=1,v1=abc", ResponseBody: "boom"},
+	}
+
+	var buf bytes.Buffer
+	if err := ExportHAR(&buf, entries, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	var har harFile
+	if err := json.Unmarshal(buf.Bytes(), &har); err != nil {
+		t.Fatal(err)
+	}
+	if len(har.Log.Entries) != 1 {
+		t.Fatalf("expected 1 HAR entry, got %d", len(har.Log.Entries))
+	}
+
+	e := har.Log.Entries[0]
+	if e.Request.PostData == nil || e.Request.PostData.Text != `{"v":1}` {
+		t.Fatalf("expected the received payload as the request body, got %+v", e.Request.PostData)
+	}
+	if len(e.Request.Headers) != 2 || e.Request.Headers[1] != (harHeader{Name: crypto.SignatureHeader, Value: "t=1,v1=abc"}) {
+		t.Fatalf("expected the signature header, got %+v", e.Request.Headers)
+	}
+	if e.Response.Status != 500 || e.Response.Content.Text != "boom" || e.StartedDateTime != "2026-01-01T10:00:00.98Z" {
+		t.Fatalf("unexpected HAR entry %+v", e)
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := ExportCSV(&buf, []LogEntry{
+		{Msg: MsgForwarded, ID: "evt_1", Time: "2026-01-01T10:00:00Z", StatusCode: 200},
+		{Msg: MsgForwardError, ID: "evt_2", Time: "2026-01-01T10:00:01Z", StatusCode: 500, Signature: "t=1,v1=abc", ResponseBody: `{"error":"boom"}`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || lines[0] != "time,msg,event,id,delivery_id,url,status_code,duration_ms,size_bytes,error,signature,response_body,raw_message" {
+		t.Fatalf("unexpected CSV %q", buf.String())
+	}
+	if lines[1] != "2026-01-01T10:00:00Z,webhook_forwarded,,evt_1,,,200,,,,,," {
+		t.Fatalf("unexpected row %q", lines[1])
+	}
+	if lines[2] != `2026-01-01T10:00:01Z,webhook_forward_error,,evt_2,,,500,,,,"t=1,v1=abc","{""error"":""boom""}",` {
+		t.Fatalf("unexpected row %q", lines[2])
+	}
+}
@@ -0,0 +1,154 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// profilesDirName holds the logs of each profile when they are kept apart, in
+// profiles/<name>/transactions.log.
+const profilesDirName = "profiles"
+
+var unsafeProfileChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+func profileLogDir(logDir, profile string) string {
+	name := unsafeProfileChars.ReplaceAllString(profile, "_")
+	if name == "" || name == "." || name == ".." {
+		name = "_"
+	}
+	return filepath.Join(logDir, profilesDirName, name)
+}
+
+// TransactionLogPaths returns the path of the transaction log followed by those
+// of the profiles logged to separate files.
+func TransactionLogPaths() ([]string, error) {
+	logPath, err := GetLogFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := filepath.Glob(filepath.Join(filepath.Dir(logPath), profilesDirName, "*", "transactions.log"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(matches)
+
+	return append([]string{logPath}, matches...), nil
+}
+
+// AllLogFiles returns the files of every transaction log, backups included.
+func AllLogFiles() ([]string, error) {
+	paths, err := TransactionLogPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, path := range paths {
+		logFiles, err := LogFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, logFiles...)
+	}
+	return files, nil
+}
+
+// backupTimeFormat is the timestamp lumberjack puts in the names of rotated
+// files, e.g. transactions-2026-01-02T15-04-05.000.log.gz.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// LogFiles returns the rotated backups of the log file at path, oldest first,
+// followed by path itself. Files that don't exist are left out.
+func LogFiles(path string) ([]string, error) {
+	dir := filepath.Dir(path)
+	ext := filepath.Ext(path)
+	prefix := strings.TrimSuffix(filepath.Base(path), ext) + "-"
+
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read log directory: %w", err)
+	}
+
+	type backup struct {
+		path string
+		time time.Time
+	}
+	var backups []backup
+
+	for _, e := range dirEntries {
+		if e.IsDir() {
+			continue
+		}
+
+		name := e.Name()
+		stamp, ok := strings.CutPrefix(name, prefix)
+		if !ok {
+			continue
+		}
+		stamp = strings.TrimSuffix(stamp, ".gz")
+		stamp, ok = strings.CutSuffix(stamp, ext)
+		if !ok {
+			continue
+		}
+
+		t, err := time.Parse(backupTimeFormat, stamp)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{path: filepath.Join(dir, name), time: t})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].time.Before(backups[j].time)
+	})
+
+	files := make([]string, 0, len(backups)+1)
+	for _, b := range backups {
+		files = append(files, b.path)
+	}
+	if _, err := os.Stat(path); err == nil {
+		files = append(files, path)
+	}
+
+	return files, nil
+}
+
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (g *gzipFile) Close() error {
+	g.Reader.Close()
+	return g.file.Close()
+}
+
+// openLogFile opens a log file, decompressing it when it's gzipped.
+func openLogFile(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasSuffix(path, ".gz") {
+		return f, nil
+	}
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to decompress %s: %w", path, err)
+	}
+	return &gzipFile{Reader: zr, file: f}, nil
+}
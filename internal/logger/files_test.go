@@ -0,0 +1,137 @@
+package logger
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLogFiles_BackupsOldestFirst(t *testing.T) {
+	dir := t.TempDir()
+	current := filepath.Join(dir, "transactions.log")
+
+	write := func(name, content string) {
+		t.Helper()
+		f, err := os.Create(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		if filepath.Ext(name) != ".gz" {
+			f.WriteString(content)
+			return
+		}
+		zw := gzip.NewWriter(f)
+		zw.Write([]byte(content))
+		zw.Close()
+	}
+
+	write("transactions.log", `{"time":"2026-03-01T00:00:00Z","msg":"webhook_received","id":"evt_3"}`+"\n")
+	write("transactions-2026-02-01T00-00-00.000.log", `{"time":"2026-02-01T00:00:00Z","msg":"webhook_received","id":"evt_2"}`+"\n")
+	write("transactions-2026-01-01T00-00-00.000.log.gz", `{"time":"2026-01-01T00:00:00Z","msg":"webhook_received","id":"evt_1"}`+"\n")
+	write("abacatepay-2026-01-01T00-00-00.000.log", "")
+
+	files, err := LogFiles(current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 3 || files[2] != current {
+		t.Fatalf("unexpected files %v", files)
+	}
+
+	var ids []string
+	if err := scanLogFiles(files, func(e LogEntry) { ids = append(ids, e.ID) }); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 3 || ids[0] != "evt_1" || ids[1] != "evt_2" || ids[2] != "evt_3" {
+		t.Fatalf("expected entries in chronological order, got %v", ids)
+	}
+}
+
+func TestProfileLogDir_Sanitizes(t *testing.T) {
+	tests := map[string]string{
+		"staging":     "staging",
+		"my-app_1.0":  "my-app_1.0",
+		"../../etc":   ".._.._etc",
+		"a/b":         "a_b",
+		"olá mundo":   "ol__mundo",
+		"":            "_",
+		".":           "_",
+		"..":          "_",
+		`C:\profiles`: "C__profiles",
+	}
+
+	for profile, want := range tests {
+		got := profileLogDir("/logs", profile)
+		if got != filepath.Join("/logs", profilesDirName, want) {
+			t.Errorf("profileLogDir(%q) = %q, want %q", profile, got, want)
+		}
+	}
+}
+
+// setupProfileLogs writes the shared log and the logs of two profiles, one of
+// them with a rotated backup, in a temporary home directory and returns the
+// log directory.
+func setupProfileLogs(t *testing.T) string {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".abacatepay", "logs")
+
+	for _, name := range []string{"staging", "dev"} {
+		if err := os.MkdirAll(profileLogDir(dir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeFile(t, filepath.Join(dir, "transactions.log"), `{"msg":"webhook_received","id":"evt_shared"}`+"\n")
+	writeFile(t, filepath.Join(profileLogDir(dir, "staging"), "transactions.log"), `{"msg":"webhook_received","id":"evt_staging"}`+"\n")
+	writeFile(t, filepath.Join(profileLogDir(dir, "staging"), "transactions-2026-01-01T00-00-00.000.log.gz"), `{"msg":"webhook_received","id":"evt_staging_old"}`+"\n")
+	writeFile(t, filepath.Join(profileLogDir(dir, "dev"), "transactions.log"), `{"msg":"webhook_received","id":"evt_dev"}`+"\n")
+	return dir
+}
+
+func TestTransactionLogPaths_IncludesProfiles(t *testing.T) {
+	dir := setupProfileLogs(t)
+
+	paths, err := TransactionLogPaths()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		filepath.Join(dir, "transactions.log"),
+		filepath.Join(dir, profilesDirName, "dev", "transactions.log"),
+		filepath.Join(dir, profilesDirName, "staging", "transactions.log"),
+	}
+	if !slices.Equal(paths, want) {
+		t.Fatalf("expected %v, got %v", want, paths)
+	}
+
+	files, err := AllLogFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want = []string{
+		filepath.Join(dir, "transactions.log"),
+		filepath.Join(dir, profilesDirName, "dev", "transactions.log"),
+		filepath.Join(dir, profilesDirName, "staging", "transactions-2026-01-01T00-00-00.000.log.gz"),
+		filepath.Join(dir, profilesDirName, "staging", "transactions.log"),
+	}
+	if !slices.Equal(files, want) {
+		t.Fatalf("expected %v, got %v", want, files)
+	}
+
+	var ids []string
+	if err := scanLogFiles(files, func(e LogEntry) { ids = append(ids, e.ID) }); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(ids, []string{"evt_shared", "evt_dev", "evt_staging_old", "evt_staging"}) {
+		t.Fatalf("expected the entries of every profile, got %v", ids)
+	}
+}
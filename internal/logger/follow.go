@@ -0,0 +1,184 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+)
+
+// Follower reads the entries appended to the transaction logs by any process,
+// like tail -f. It starts at the end of the logs as they were when created and
+// follows lumberjack rotations, purges and profile logs created later.
+type Follower struct {
+	files map[string]*followedFile
+}
+
+// followedFile is the state of one transaction log being followed.
+type followedFile struct {
+	path    string
+	file    *os.File
+	info    os.FileInfo
+	offset  int64
+	partial []byte
+}
+
+func NewFollower() (*Follower, error) {
+	paths, err := TransactionLogPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Follower{files: map[string]*followedFile{}}
+	for _, path := range paths {
+		ff := &followedFile{path: path}
+		if err := ff.open(); err != nil {
+			f.Close()
+			return nil, err
+		}
+		if ff.info != nil {
+			ff.offset = ff.info.Size()
+		}
+		f.files[path] = ff
+	}
+	return f, nil
+}
+
+func (f *Follower) Close() {
+	for _, ff := range f.files {
+		if ff.file != nil {
+			ff.file.Close()
+		}
+	}
+}
+
+// Run calls fn for every new entry, checking the logs every interval, until
+// ctx is done.
+func (f *Follower) Run(ctx context.Context, interval time.Duration, fn func(LogEntry)) error {
+	defer f.Close()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := f.poll(fn); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (f *Follower) poll(fn func(LogEntry)) error {
+	paths, err := TransactionLogPaths()
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		ff, ok := f.files[path]
+		if !ok {
+			ff = &followedFile{path: path}
+			f.files[path] = ff
+		}
+		if err := ff.poll(fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *followedFile) open() error {
+	file, err := os.Open(f.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	f.file, f.info, f.offset, f.partial = file, info, 0, nil
+	return nil
+}
+
+func (f *followedFile) poll(fn func(LogEntry)) error {
+	if f.file != nil {
+		if err := f.read(fn); err != nil {
+			return err
+		}
+	}
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read log file: %w", err)
+	}
+
+	switch {
+	case f.file == nil || !os.SameFile(f.info, info):
+		// Rotated: the old file was read to the end above.
+		if f.file != nil {
+			f.file.Close()
+			f.file = nil
+		}
+		if err := f.open(); err != nil {
+			return err
+		}
+		return f.read(fn)
+	case info.Size() < f.offset:
+		// Truncated in place by 'logs purge'.
+		f.offset, f.partial = 0, nil
+		return f.read(fn)
+	}
+	return nil
+}
+
+func (f *followedFile) read(fn func(LogEntry)) error {
+	if _, err := f.file.Seek(f.offset, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read log file: %w", err)
+	}
+
+	data, err := io.ReadAll(f.file)
+	if err != nil {
+		return fmt.Errorf("failed to read log file: %w", err)
+	}
+	f.offset += int64(len(data))
+
+	data = append(f.partial, data...)
+	end := bytes.LastIndexByte(data, '\n')
+	if end < 0 {
+		f.partial = data
+		return nil
+	}
+	f.partial = append([]byte(nil), data[end+1:]...)
+
+	for _, line := range bytes.Split(data[:end], []byte{'\n'}) {
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry LogEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			slog.Debug("skipped malformed log entry", "error", err)
+			continue
+		}
+		fn(entry)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestFollower_ReadsAppendedAndRotatedEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transactions.log")
+	if err := os.WriteFile(path, []byte(`{"msg":"webhook_received","id":"evt_old"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	f := &followedFile{path: path}
+	if err := f.open(); err != nil {
+		t.Fatal(err)
+	}
+	f.offset = f.info.Size()
+
+	var ids []string
+	collect := func(e LogEntry) { ids = append(ids, e.ID) }
+
+	appendLine := func(line string) {
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file.WriteString(line)
+		file.Close()
+	}
+
+	appendLine(`{"msg":"webhook_received","id":"evt_1"}` + "\n" + `{"msg":"webhook_forwarded",`)
+	if err := f.poll(collect); err != nil {
+		t.Fatal(err)
+	}
+	appendLine(`"id":"evt_1"}` + "\n")
+
+	// lumberjack renames the full file and starts a new one.
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	appendLine(`{"msg":"webhook_received","id":"evt_2"}` + "\n")
+
+	if err := f.poll(collect); err != nil {
+		t.Fatal(err)
+	}
+	f.file.Close()
+
+	if len(ids) != 3 || ids[0] != "evt_1" || ids[1] != "evt_1" || ids[2] != "evt_2" {
+		t.Fatalf("unexpected entries %v", ids)
+	}
+}
+
+func TestFollower_FollowsEveryProfile(t *testing.T) {
+	dir := setupProfileLogs(t)
+
+	f, err := NewFollower()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	appendLine := func(path, line string) {
+		t.Helper()
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file.WriteString(line + "\n")
+		file.Close()
+	}
+
+	appendLine(filepath.Join(dir, "transactions.log"), `{"msg":"webhook_received","id":"evt_shared_new"}`)
+	appendLine(filepath.Join(profileLogDir(dir, "staging"), "transactions.log"), `{"msg":"webhook_received","id":"evt_staging_new"}`)
+
+	// A profile that starts logging to its own file after following started is
+	// read from the beginning.
+	if err := os.MkdirAll(profileLogDir(dir, "prod"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	appendLine(filepath.Join(profileLogDir(dir, "prod"), "transactions.log"), `{"msg":"webhook_received","id":"evt_prod"}`)
+
+	var ids []string
+	if err := f.poll(func(e LogEntry) { ids = append(ids, e.ID) }); err != nil {
+		t.Fatal(err)
+	}
+
+	slices.Sort(ids)
+	if !slices.Equal(ids, []string{"evt_prod", "evt_shared_new", "evt_staging_new"}) {
+		t.Fatalf("expected only the new entries of every file, got %v", ids)
+	}
+}
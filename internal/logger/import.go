@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+)
+
+type ImportResult struct {
+	Files    int `json:"files"`
+	Lines    int `json:"lines"`
+	Imported int `json:"imported"`
+	Skipped  int `json:"skipped"`
+}
+
+// ImportFile copies the entries of a transaction log file into the store.
+// Entries already in the store are skipped, so importing twice is harmless.
+func (s *Store) ImportFile(path string) (ImportResult, error) {
+	f, err := openLogFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ImportResult{}, nil
+		}
+		return ImportResult{}, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	result, err := s.importReader(f)
+	if err != nil {
+		return result, fmt.Errorf("failed to import %s: %w", path, err)
+	}
+	result.Files = 1
+	return result, nil
+}
+
+func (s *Store) importReader(r io.Reader) (ImportResult, error) {
+	var result ImportResult
+
+	ctx := context.Background()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return result, err
+	}
+	defer tx.Rollback()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		result.Lines++
+
+		inserted, err := insertLine(ctx, tx, line)
+		if err != nil {
+			slog.Debug("skipped malformed log entry", "error", err)
+			result.Skipped++
+			continue
+		}
+		if inserted {
+			result.Imported++
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return result, err
+	}
+
+	return result, tx.Commit()
+}
@@ -6,10 +6,21 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
+// Console formats accepted by Config.Format.
+const (
+	FormatText   = "text"
+	FormatJSON   = "json"
+	FormatLogfmt = "logfmt"
+)
+
+var ConsoleFormats = []string{FormatText, FormatJSON, FormatLogfmt}
+
 type Config struct {
 	LogDir     string
 	MaxSize    int
@@ -17,6 +28,12 @@ type Config struct {
 	MaxAge     int
 	Compress   bool
 	Level      slog.Level
+	// Levels overrides Level for subsystems, see Subsystem.
+	Levels map[string]slog.Level
+	// Format is the format of the lines written to stderr.
+	Format string
+	// File is the log file, abacatepay.log in LogDir when empty.
+	File string
 }
 
 func DefaultConfig() (*Config, error) {
@@ -32,60 +49,142 @@ func DefaultConfig() (*Config, error) {
 		MaxAge:     30,
 		Compress:   true,
 		Level:      slog.LevelInfo,
+		Format:     FormatText,
 	}, nil
 }
 
 func Setup(cfg *Config) (*slog.Logger, error) {
-	if err := os.MkdirAll(cfg.LogDir, 0o755); err != nil {
+	filename := cfg.File
+	if filename == "" {
+		filename = filepath.Join(cfg.LogDir, "abacatepay.log")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
 		return nil, fmt.Errorf("failed to create log directory: %w", err)
 	}
 
+	consoleHandler, err := newConsoleHandler(cfg.Format, minLevel(cfg.Level, cfg.Levels))
+	if err != nil {
+		return nil, err
+	}
+
 	logFile := &lumberjack.Logger{
-		Filename:   filepath.Join(cfg.LogDir, "abacatepay.log"),
+		Filename:   filename,
 		MaxSize:    cfg.MaxSize,
 		MaxBackups: cfg.MaxBackups,
 		MaxAge:     cfg.MaxAge,
 		Compress:   cfg.Compress,
 	}
 
-	fileHandler := slog.NewJSONHandler(logFile, &slog.HandlerOptions{Level: cfg.Level})
-
-	consoleHandler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
-		Level: cfg.Level,
-		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
-			if a.Key == slog.TimeKey && len(groups) == 0 {
-				return slog.Attr{}
-			}
-			return a
-		},
-	})
+	fileHandler := slog.NewJSONHandler(logFile, &slog.HandlerOptions{Level: minLevel(cfg.Level, cfg.Levels)})
 
 	multiHandler := NewFanoutHandler(consoleHandler, fileHandler)
 
-	logger := slog.New(multiHandler)
+	logger := slog.New(newLevelHandler(multiHandler, cfg.Level, cfg.Levels))
 	slog.SetDefault(logger)
 
 	return logger, nil
 }
 
-func NewTransactionLogger(cfg *Config) (*slog.Logger, error) {
+// SetupConsole makes the default logger write to stderr only, with the format
+// and levels of cfg. It is the fallback when the log file can't be used.
+func SetupConsole(cfg *Config) (*slog.Logger, error) {
+	consoleHandler, err := newConsoleHandler(cfg.Format, minLevel(cfg.Level, cfg.Levels))
+	if err != nil {
+		return nil, err
+	}
+
+	logger := slog.New(newLevelHandler(consoleHandler, cfg.Level, cfg.Levels))
+	slog.SetDefault(logger)
+
+	return logger, nil
+}
+
+// newConsoleHandler returns the handler of stderr: a format meant to be read
+// by people for text, key=value pairs with the time for logfmt, or JSON.
+func newConsoleHandler(format string, level slog.Level) (slog.Handler, error) {
+	opts := &slog.HandlerOptions{Level: level}
+
+	switch format {
+	case FormatText, "":
+		return newTextHandler(os.Stderr, level), nil
+	case FormatLogfmt:
+		return slog.NewTextHandler(os.Stderr, opts), nil
+	case FormatJSON:
+		return slog.NewJSONHandler(os.Stderr, opts), nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q (valid: %s)", format, strings.Join(ConsoleFormats, ", "))
+	}
+}
+
+// Tags are attached to every line of the transaction log.
+type Tags struct {
+	Profile string
+	Env     string
+}
+
+// NewTransactionLogger returns the logger of webhook deliveries. Lines go to
+// transactions.log and the event store, after the log settings are applied:
+// entries older than the retention period are dropped and PII fields are
+// masked when redaction is on.
+func NewTransactionLogger(cfg *Config, tags Tags) (*slog.Logger, error) {
 	if err := os.MkdirAll(cfg.LogDir, 0o755); err != nil {
 		return nil, fmt.Errorf("failed to create log directory: %w", err)
 	}
 
+	settings, err := loadSettings(filepath.Join(cfg.LogDir, settingsFileName))
+	if err != nil {
+		slog.Warn("invalid log settings, using the defaults with redaction on", "error", err)
+	}
+
+	fileDir := cfg.LogDir
+	if settings.SeparateProfiles && tags.Profile != "" {
+		fileDir = profileLogDir(cfg.LogDir, tags.Profile)
+		if err := os.MkdirAll(fileDir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create log directory: %w", err)
+		}
+	}
+
 	logFile := &lumberjack.Logger{
-		Filename:   filepath.Join(cfg.LogDir, "transactions.log"),
+		Filename:   filepath.Join(fileDir, "transactions.log"),
 		MaxSize:    cfg.MaxSize,
 		MaxBackups: cfg.MaxBackups,
-		MaxAge:     cfg.MaxAge,
+		MaxAge:     settings.RetentionDays,
 		Compress:   cfg.Compress,
 	}
 
-	handler := slog.NewJSONHandler(logFile, &slog.HandlerOptions{
+	opts := &slog.HandlerOptions{
 		Level: slog.LevelInfo,
-	})
+	}
 
-	return slog.New(handler), nil
+	var handler slog.Handler = slog.NewJSONHandler(logFile, opts)
+
+	// The log file stays the source of truth; the store is an index on top of it
+	// and is rebuilt from the file by 'logs import' if it's ever missing.
+	store, err := openStoreIn(cfg.LogDir)
+	if err != nil {
+		slog.Debug("event store unavailable, logging to file only", "error", err)
+	} else {
+		if cutoff := settings.RetentionCutoff(time.Now()); !cutoff.IsZero() {
+			if _, err := store.Purge(context.Background(), PurgeOptions{Before: cutoff}); err != nil {
+				slog.Debug("failed to apply log retention", "error", err)
+			}
+		}
+		handler = NewFanoutHandler(handler, newStoreHandler(store, opts))
+	}
+
+	if settings.Redact {
+		handler = newRedactHandler(handler, settings.RedactFields)
+	}
+
+	logger := slog.New(handler)
+	if tags.Profile != "" {
+		logger = logger.With("profile", tags.Profile)
+	}
+	if tags.Env != "" {
+		logger = logger.With("env", tags.Env)
+	}
+	return logger, nil
 }
 
 func NewConsoleLogger(level slog.Level) *slog.Logger {
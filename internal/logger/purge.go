@@ -0,0 +1,161 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+type PurgeOptions struct {
+	// Before removes entries written before this time. Zero matches any time.
+	Before time.Time
+	// Profile removes only entries logged under this profile.
+	Profile string
+	DryRun  bool
+}
+
+func (o PurgeOptions) matches(e LogEntry) bool {
+	if !o.Before.IsZero() && !e.ParsedTime().Before(o.Before) {
+		return false
+	}
+	if o.Profile != "" && e.Profile != o.Profile {
+		return false
+	}
+	return true
+}
+
+type PurgeResult struct {
+	Files   int `json:"files"`
+	Removed int `json:"removed"`
+	Kept    int `json:"kept"`
+}
+
+// Purge removes matching entries from the transaction logs, their rotated
+// backups and the event store. Backups left empty are deleted.
+func Purge(opts PurgeOptions) (PurgeResult, error) {
+	var result PurgeResult
+
+	paths, err := TransactionLogPaths()
+	if err != nil {
+		return result, err
+	}
+
+	files, err := AllLogFiles()
+	if err != nil {
+		return result, err
+	}
+
+	for _, path := range files {
+		removed, kept, err := purgeFile(path, slices.Contains(paths, path), opts)
+		if err != nil {
+			return result, err
+		}
+		if removed > 0 {
+			result.Files++
+		}
+		result.Removed += removed
+		result.Kept += kept
+	}
+
+	if opts.DryRun {
+		return result, nil
+	}
+
+	store, err := OpenStore()
+	if err != nil {
+		return result, err
+	}
+	defer store.Close()
+
+	if _, err := store.Purge(context.Background(), opts); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func purgeFile(path string, current bool, opts PurgeOptions) (removed, kept int, err error) {
+	r, err := openLogFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, 0, nil
+		}
+		return 0, 0, fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	var out bytes.Buffer
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry LogEntry
+		if json.Unmarshal(line, &entry) == nil && opts.matches(entry) {
+			removed++
+			continue
+		}
+
+		kept++
+		out.Write(line)
+		out.WriteByte('\n')
+	}
+	r.Close()
+
+	if err := scanner.Err(); err != nil {
+		return 0, 0, fmt.Errorf("error reading log file %s: %w", path, err)
+	}
+
+	if removed == 0 || opts.DryRun {
+		return removed, kept, nil
+	}
+
+	if kept == 0 && !current {
+		return removed, kept, os.Remove(path)
+	}
+
+	return removed, kept, rewriteLogFile(path, out.Bytes())
+}
+
+// rewriteLogFile replaces the content of a log file. The current log is
+// truncated in place so a running listener keeps appending to the same file;
+// compressed backups are replaced atomically.
+func rewriteLogFile(path string, data []byte) error {
+	if !strings.HasSuffix(path, ".gz") {
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return fmt.Errorf("failed to rewrite %s: %w", path, err)
+		}
+		return nil
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".purge-*.gz")
+	if err != nil {
+		return fmt.Errorf("failed to rewrite %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	zw := gzip.NewWriter(tmp)
+	if _, err := zw.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to rewrite %s: %w", path, err)
+	}
+	if err := zw.Close(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to rewrite %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to rewrite %s: %w", path, err)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,139 @@
+package logger
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	purgeOld    = `{"time":"2026-01-01T00:00:00Z","msg":"webhook_received","id":"evt_old","profile":"staging"}`
+	purgeOldDev = `{"time":"2026-01-02T00:00:00Z","msg":"webhook_received","id":"evt_old_dev","profile":"dev"}`
+	purgeNew    = `{"time":"2026-03-01T00:00:00Z","msg":"webhook_received","id":"evt_new","profile":"staging"}`
+)
+
+// setupPurgeLogs writes a current log, a plain backup and a gzip backup in a
+// temporary home directory and returns the log directory.
+func setupPurgeLogs(t *testing.T) string {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".abacatepay", "logs")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	writeFile(t, filepath.Join(dir, "transactions-2026-01-01T00-00-00.000.log.gz"), purgeOld+"\n")
+	writeFile(t, filepath.Join(dir, "transactions-2026-01-02T00-00-00.000.log"), purgeOldDev+"\n")
+	writeFile(t, filepath.Join(dir, "transactions.log"), purgeOld+"\n"+purgeNew+"\n")
+	return dir
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var w io.Writer = f
+	if strings.HasSuffix(path, ".gz") {
+		zw := gzip.NewWriter(f)
+		defer zw.Close()
+		w = zw
+	}
+	if _, err := io.WriteString(w, content); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	r, err := openLogFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestPurge_Before(t *testing.T) {
+	dir := setupPurgeLogs(t)
+
+	result, err := Purge(PurgeOptions{Before: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Removed != 3 || result.Kept != 1 || result.Files != 3 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	// Backups left empty are deleted, the current log is truncated in place.
+	for _, name := range []string{"transactions-2026-01-01T00-00-00.000.log.gz", "transactions-2026-01-02T00-00-00.000.log"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be deleted, got %v", name, err)
+		}
+	}
+	if got := readFile(t, filepath.Join(dir, "transactions.log")); got != purgeNew+"\n" {
+		t.Fatalf("unexpected current log %q", got)
+	}
+}
+
+func TestPurge_ProfileRewritesGzipBackups(t *testing.T) {
+	dir := setupPurgeLogs(t)
+	backup := filepath.Join(dir, "transactions-2026-01-01T00-00-00.000.log.gz")
+	writeFile(t, backup, purgeOld+"\n"+purgeOldDev+"\n")
+
+	result, err := Purge(PurgeOptions{Profile: "staging"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Removed != 3 || result.Kept != 2 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	if got := readFile(t, backup); got != purgeOldDev+"\n" {
+		t.Fatalf("unexpected gzip backup %q", got)
+	}
+	if got := readFile(t, filepath.Join(dir, "transactions.log")); got != "" {
+		t.Fatalf("expected the current log to be emptied but kept, got %q", got)
+	}
+	if got := readFile(t, filepath.Join(dir, "transactions-2026-01-02T00-00-00.000.log")); got != purgeOldDev+"\n" {
+		t.Fatalf("expected the dev backup to be untouched, got %q", got)
+	}
+}
+
+func TestPurge_DryRunChangesNothing(t *testing.T) {
+	dir := setupPurgeLogs(t)
+
+	result, err := Purge(PurgeOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Removed != 4 || result.Kept != 0 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	if got := readFile(t, filepath.Join(dir, "transactions-2026-01-01T00-00-00.000.log.gz")); got != purgeOld+"\n" {
+		t.Fatalf("expected the gzip backup to be untouched, got %q", got)
+	}
+	if got := readFile(t, filepath.Join(dir, "transactions.log")); got != purgeOld+"\n"+purgeNew+"\n" {
+		t.Fatalf("expected the current log to be untouched, got %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, storeFileName)); !os.IsNotExist(err) {
+		t.Fatalf("expected the dry run not to open the event store, got %v", err)
+	}
+}
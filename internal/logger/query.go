@@ -0,0 +1,374 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"abacatepay-cli/internal/payload"
+)
+
+// Query narrows log entries down beyond what ReadOptions can. Filters on the
+// payload (ResourceID, Search and Where) apply to every entry of an event, so
+// the forward lines of a matching event are kept along with it.
+type Query struct {
+	Profile     string
+	Env         string
+	Events      []string
+	ResourceID  string
+	StatusCodes *StatusRange
+	URL         string
+	MinDuration time.Duration
+	Search      string
+	Where       []Condition
+}
+
+func (q Query) filtersPayload() bool {
+	return q.ResourceID != "" || q.Search != "" || len(q.Where) > 0
+}
+
+// SearchLogs reads the entries selected by opts and keeps the ones matching q.
+// The limit is applied after filtering. The store runs the whole query unless
+// q filters on the payload, which needs every entry of an event to be read.
+func SearchLogs(q Query, opts ReadOptions) ([]LogEntry, error) {
+	if !opts.CurrentOnly && !q.filtersPayload() {
+		store, err := OpenStore()
+		if err == nil {
+			defer store.Close()
+			return store.Search(context.Background(), q, opts)
+		}
+		slog.Debug("event store unavailable, reading the log files", "error", err)
+	}
+
+	limit := opts.Limit
+	opts.Limit = 0
+
+	entries, err := ReadTransactionLogs(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	entries = q.Filter(entries)
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return entries, nil
+}
+
+func (q Query) Filter(entries []LogEntry) []LogEntry {
+	var payloads map[string]any
+	if q.filtersPayload() {
+		payloads = map[string]any{}
+		for _, e := range entries {
+			if e.ID == "" || e.RawMessage == "" {
+				continue
+			}
+			if doc, err := payload.Decode([]byte(e.RawMessage)); err == nil {
+				payloads[e.ID] = doc
+			}
+		}
+	}
+
+	var matched []LogEntry
+	for _, e := range entries {
+		if q.matchEntry(e) && (!q.filtersPayload() || q.matchPayload(e, payloads[e.ID])) {
+			matched = append(matched, e)
+		}
+	}
+	return matched
+}
+
+// Matcher returns a function reporting whether entries seen one at a time
+// match q. Payloads are remembered from the received entries it's given until
+// their delivery is forwarded, so the forward of a matching event matches too.
+func (q Query) Matcher() func(LogEntry) bool {
+	return newMatcher(q).match
+}
+
+type matcher struct {
+	q        Query
+	payloads map[string]any
+}
+
+func newMatcher(q Query) *matcher {
+	return &matcher{q: q, payloads: map[string]any{}}
+}
+
+func (m *matcher) match(e LogEntry) bool {
+	if !m.q.filtersPayload() {
+		return m.q.matchEntry(e)
+	}
+
+	// Entries written before delivery IDs were logged are paired by event ID,
+	// as in GroupDeliveries.
+	key := e.DeliveryID
+	if key == "" {
+		key = e.ID
+	}
+
+	if key != "" && e.RawMessage != "" {
+		if doc, err := payload.Decode([]byte(e.RawMessage)); err == nil {
+			m.payloads[key] = doc
+		}
+	}
+
+	doc := m.payloads[key]
+	if isForwardMsg(e.Msg) {
+		delete(m.payloads, key)
+	}
+	return m.q.matchEntry(e) && m.q.matchPayload(e, doc)
+}
+
+func (q Query) matchEntry(e LogEntry) bool {
+	if q.Profile != "" && e.Profile != q.Profile {
+		return false
+	}
+	if q.Env != "" && e.Env != q.Env {
+		return false
+	}
+	if len(q.Events) > 0 && !contains(q.Events, e.Event) {
+		return false
+	}
+	if q.StatusCodes != nil && !q.StatusCodes.Contains(e.StatusCode) {
+		return false
+	}
+	if q.URL != "" && !strings.Contains(e.URL, q.URL) {
+		return false
+	}
+	if q.MinDuration > 0 && time.Duration(e.DurationMs)*time.Millisecond < q.MinDuration {
+		return false
+	}
+	return true
+}
+
+func (q Query) matchPayload(e LogEntry, doc any) bool {
+	if q.Search != "" {
+		raw := e.RawMessage
+		if raw == "" && doc != nil {
+			data, _ := json.Marshal(doc)
+			raw = string(data)
+		}
+		if !strings.Contains(strings.ToLower(raw), strings.ToLower(q.Search)) {
+			return false
+		}
+	}
+
+	if q.ResourceID != "" && e.ID != q.ResourceID && !hasID(doc, q.ResourceID) {
+		return false
+	}
+
+	for _, c := range q.Where {
+		if !c.Match(doc) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasID reports whether any "id" field in doc equals id.
+func hasID(doc any, id string) bool {
+	switch node := doc.(type) {
+	case map[string]any:
+		if v, ok := node["id"].(string); ok && v == id {
+			return true
+		}
+		for _, child := range node {
+			if hasID(child, id) {
+				return true
+			}
+		}
+	case []any:
+		for _, child := range node {
+			if hasID(child, id) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusRange is an inclusive range of HTTP status codes.
+type StatusRange struct {
+	Min, Max int
+}
+
+func (r StatusRange) Contains(code int) bool {
+	return code >= r.Min && code <= r.Max
+}
+
+// ParseStatusRange accepts a code (404), a class (5xx), a range (400-499) or a
+// bound (>=400, <300).
+func ParseStatusRange(s string) (*StatusRange, error) {
+	s = strings.TrimSpace(s)
+	invalid := fmt.Errorf("invalid status code range %q. Expected 404, 5xx, 400-499 or >=400", s)
+
+	if class, ok := strings.CutSuffix(strings.ToLower(s), "xx"); ok {
+		n, err := strconv.Atoi(class)
+		if err != nil || n < 1 || n > 5 {
+			return nil, invalid
+		}
+		return &StatusRange{Min: n * 100, Max: n*100 + 99}, nil
+	}
+
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		rest, ok := strings.CutPrefix(s, op)
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, invalid
+		}
+		switch op {
+		case ">=":
+			return &StatusRange{Min: n, Max: 999}, nil
+		case ">":
+			return &StatusRange{Min: n + 1, Max: 999}, nil
+		case "<=":
+			return &StatusRange{Min: 1, Max: n}, nil
+		default:
+			return &StatusRange{Min: 1, Max: n - 1}, nil
+		}
+	}
+
+	if lo, hi, ok := strings.Cut(s, "-"); ok {
+		from, err1 := strconv.Atoi(strings.TrimSpace(lo))
+		to, err2 := strconv.Atoi(strings.TrimSpace(hi))
+		if err1 != nil || err2 != nil || from > to {
+			return nil, invalid
+		}
+		return &StatusRange{Min: from, Max: to}, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return nil, invalid
+	}
+	return &StatusRange{Min: n, Max: n}, nil
+}
+
+// Condition compares the value at a dotted path of the payload, as in
+// "data.billing.amount > 10000" or "data.billing.status == PAID".
+type Condition struct {
+	Path  string
+	Op    string
+	Value any
+}
+
+// conditionOps is ordered so that longer operators are tried first.
+var conditionOps = []string{"==", "!=", ">=", "<=", "~=", "=", ">", "<"}
+
+func ParseCondition(s string) (Condition, error) {
+	for i := 0; i < len(s); i++ {
+		for _, op := range conditionOps {
+			if !strings.HasPrefix(s[i:], op) {
+				continue
+			}
+
+			path := strings.TrimSpace(s[:i])
+			raw := strings.TrimSpace(s[i+len(op):])
+			if path == "" || raw == "" {
+				return Condition{}, fmt.Errorf("invalid condition %q. Expected: path op value", s)
+			}
+			if op == "=" {
+				op = "=="
+			}
+			return Condition{Path: path, Op: op, Value: parseConditionValue(raw)}, nil
+		}
+	}
+
+	return Condition{}, fmt.Errorf("invalid condition %q. Expected an operator (==, !=, >, >=, <, <=, ~=)", s)
+}
+
+func parseConditionValue(raw string) any {
+	if len(raw) >= 2 && raw[0] == '\'' && raw[len(raw)-1] == '\'' {
+		return raw[1 : len(raw)-1]
+	}
+	return payload.ParseValue(raw)
+}
+
+func (c Condition) Match(doc any) bool {
+	if doc == nil {
+		return false
+	}
+
+	v, ok := payload.Get(doc, c.Path)
+	if !ok {
+		return c.Op == "!="
+	}
+
+	if c.Op == "~=" {
+		return strings.Contains(strings.ToLower(fmt.Sprint(v)), strings.ToLower(fmt.Sprint(c.Value)))
+	}
+
+	cmp, ok := compareValues(v, c.Value)
+	if !ok {
+		return c.Op == "!="
+	}
+
+	switch c.Op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
+
+// compareValues compares numbers numerically and anything else as text.
+func compareValues(a, b any) (int, bool) {
+	if x, ok := toFloat(a); ok {
+		y, ok := toFloat(b)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	}
+
+	switch a.(type) {
+	case map[string]any, []any:
+		return 0, false
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b)), true
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	}
+	return 0, false
+}
@@ -0,0 +1,93 @@
+package logger
+
+import "testing"
+
+func TestParseStatusRange(t *testing.T) {
+	cases := map[string]StatusRange{
+		"404":     {404, 404},
+		"5xx":     {500, 599},
+		"400-499": {400, 499},
+		">=400":   {400, 999},
+		"<300":    {1, 299},
+	}
+	for in, want := range cases {
+		got, err := ParseStatusRange(in)
+		if err != nil || *got != want {
+			t.Errorf("ParseStatusRange(%q) = %+v, %v; want %+v", in, got, err, want)
+		}
+	}
+
+	if _, err := ParseStatusRange("9xx"); err == nil {
+		t.Error("expected an error for 9xx")
+	}
+}
+
+func TestQuery_WhereKeepsForwardsOfMatchingEvents(t *testing.T) {
+	entries := []LogEntry{
+		{Msg: MsgReceived, ID: "evt_1", RawMessage: `{"data":{"billing":{"id":"bill_1","amount":15000,"status":"PAID"}}}`},
+		{Msg: MsgForwarded, ID: "evt_1", StatusCode: 200},
+		{Msg: MsgReceived, ID: "evt_2", RawMessage: `{"data":{"billing":{"id":"bill_2","amount":500,"status":"PAID"}}}`},
+		{Msg: MsgForwardError, ID: "evt_2", StatusCode: 500},
+	}
+
+	cond, err := ParseCondition("data.billing.amount > 10000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := (Query{Where: []Condition{cond}}).Filter(entries); len(got) != 2 || got[1].Msg != MsgForwarded {
+		t.Fatalf("expected evt_1 and its forward, got %+v", got)
+	}
+
+	if got := (Query{ResourceID: "bill_2"}).Filter(entries); len(got) != 2 || got[0].ID != "evt_2" {
+		t.Fatalf("expected evt_2 entries, got %+v", got)
+	}
+
+	codes, _ := ParseStatusRange("5xx")
+	if got := (Query{StatusCodes: codes, Search: "paid"}).Filter(entries); len(got) != 1 || got[0].ID != "evt_2" {
+		t.Fatalf("expected the failed forward of evt_2, got %+v", got)
+	}
+}
+
+func TestQuery_ProfileAndEnv(t *testing.T) {
+	entries := []LogEntry{
+		{Msg: MsgReceived, ID: "evt_1", Profile: "staging", Env: "sandbox"},
+		{Msg: MsgReceived, ID: "evt_2", Profile: "prod", Env: "production"},
+		{Msg: MsgReceived, ID: "evt_3"},
+	}
+
+	if got := (Query{Env: "sandbox"}).Filter(entries); len(got) != 1 || got[0].ID != "evt_1" {
+		t.Fatalf("expected evt_1, got %+v", got)
+	}
+	if got := (Query{Profile: "prod", Env: "sandbox"}).Filter(entries); len(got) != 0 {
+		t.Fatalf("expected no entries, got %+v", got)
+	}
+}
+
+func TestMatcher_ForgetsForwardedPayloads(t *testing.T) {
+	m := newMatcher(Query{Search: "bill_1"})
+
+	entries := []struct {
+		entry LogEntry
+		want  bool
+	}{
+		{LogEntry{Msg: MsgReceived, ID: "evt_1", DeliveryID: "dlv_1", RawMessage: `{"id":"bill_1"}`}, true},
+		{LogEntry{Msg: MsgReceived, ID: "evt_1", DeliveryID: "dlv_2", RawMessage: `{"id":"bill_1"}`}, true},
+		{LogEntry{Msg: MsgForwarded, ID: "evt_1", DeliveryID: "dlv_1"}, true},
+		{LogEntry{Msg: MsgForwardFailed, ID: "evt_1", DeliveryID: "dlv_2"}, true},
+		// Legacy entries without a delivery ID are paired by event ID.
+		{LogEntry{Msg: MsgReceived, ID: "evt_2", RawMessage: `{"id":"bill_1"}`}, true},
+		{LogEntry{Msg: MsgForwardError, ID: "evt_2"}, true},
+		{LogEntry{Msg: MsgReceived, ID: "evt_3", DeliveryID: "dlv_3", RawMessage: `{"id":"bill_3"}`}, false},
+		{LogEntry{Msg: MsgForwarded, ID: "evt_3", DeliveryID: "dlv_3"}, false},
+	}
+
+	for i, tt := range entries {
+		if got := m.match(tt.entry); got != tt.want {
+			t.Errorf("entry %d: expected %v, got %v", i, tt.want, got)
+		}
+	}
+
+	if len(m.payloads) != 0 {
+		t.Fatalf("expected the payloads of forwarded deliveries to be forgotten, got %v", m.payloads)
+	}
+}
@@ -2,31 +2,58 @@ package logger
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"sort"
+	"time"
 )
 
 type LogEntry struct {
-	ID         string `json:"id"`
-	Event      string `json:"event"`
-	Time       string `json:"time"`
-	Level      string `json:"level"`
-	Msg        string `json:"msg"`
-	Timestamp  string `json:"timestamp,omitempty"`
-	URL        string `json:"url,omitempty"`
-	StatusCode int    `json:"status_code,omitempty"`
-	DurationMs int64  `json:"duration_ms,omitempty"`
-	SizeBytes  int    `json:"size_bytes,omitempty"`
-	RawMessage string `json:"raw_message,omitempty"`
-	Error      string `json:"error,omitempty"`
+	ID           string `json:"id"`
+	DeliveryID   string `json:"delivery_id,omitempty"`
+	Profile      string `json:"profile,omitempty"`
+	Env          string `json:"env,omitempty"`
+	Event        string `json:"event"`
+	Time         string `json:"time"`
+	Level        string `json:"level"`
+	Msg          string `json:"msg"`
+	Timestamp    string `json:"timestamp,omitempty"`
+	URL          string `json:"url,omitempty"`
+	StatusCode   int    `json:"status_code,omitempty"`
+	DurationMs   int64  `json:"duration_ms,omitempty"`
+	SizeBytes    int    `json:"size_bytes,omitempty"`
+	RawMessage   string `json:"raw_message,omitempty"`
+	Signature    string `json:"signature,omitempty"`
+	ResponseBody string `json:"response_body,omitempty"`
+	Error        string `json:"error,omitempty"`
 }
 
 type ReadOptions struct {
 	Limit      int
 	TypeFilter string
+	Since      time.Time
+	Until      time.Time
+	// CurrentOnly skips the event store and rotated backups and reads only
+	// the current transactions.log.
+	CurrentOnly bool
+}
+
+// includes reports whether e matches the type and time filters of opts.
+func (opts ReadOptions) includes(e LogEntry) bool {
+	if opts.TypeFilter != "" && e.Msg != opts.TypeFilter {
+		return false
+	}
+	if !opts.Since.IsZero() && e.ParsedTime().Before(opts.Since) {
+		return false
+	}
+	if !opts.Until.IsZero() && e.ParsedTime().After(opts.Until) {
+		return false
+	}
+	return true
 }
 
 func GetLogFilePath() (string, error) {
@@ -38,46 +65,38 @@ func GetLogFilePath() (string, error) {
 	return filepath.Join(homeDir, ".abacatepay", "logs", "transactions.log"), nil
 }
 
+// ReadTransactionLogs reads entries from the event store, falling back to
+// scanning the log files when the store can't be opened. With CurrentOnly,
+// only the current log file is read.
 func ReadTransactionLogs(opts ReadOptions) ([]LogEntry, error) {
-	logPath, err := GetLogFilePath()
-	if err != nil {
-		return nil, err
+	if !opts.CurrentOnly {
+		store, err := OpenStore()
+		if err == nil {
+			defer store.Close()
+			return store.Entries(context.Background(), opts)
+		}
+		slog.Debug("event store unavailable, reading the log files", "error", err)
 	}
 
-	file, err := os.Open(logPath)
+	return readLogFiles(opts)
+}
+
+func readLogFiles(opts ReadOptions) ([]LogEntry, error) {
+	files, err := transactionLogFiles(opts.CurrentOnly)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil
-		}
-		return nil, fmt.Errorf("failed to open log file: %w", err)
+		return nil, err
 	}
-	defer file.Close()
 
 	var entries []LogEntry
-	scanner := bufio.NewScanner(file)
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			continue
-		}
-
-		var entry LogEntry
-		if err := json.Unmarshal([]byte(line), &entry); err != nil {
-			slog.Debug("skipped malformed log entry", "error", err)
-			continue
+	err = scanLogFiles(files, func(entry LogEntry) {
+		if opts.includes(entry) {
+			entries = append(entries, entry)
 		}
-
-		if opts.TypeFilter != "" && entry.Msg != opts.TypeFilter {
-			continue
-		}
-
-		entries = append(entries, entry)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading log file: %w", err)
+	})
+	if err != nil {
+		return nil, err
 	}
+	sortEntries(entries)
 
 	if opts.Limit > 0 && len(entries) > opts.Limit {
 		entries = entries[len(entries)-opts.Limit:]
@@ -86,36 +105,99 @@ func ReadTransactionLogs(opts ReadOptions) ([]LogEntry, error) {
 	return entries, nil
 }
 
-func FindLogEntryByID(id string) (*LogEntry, error) {
-	logPath, err := GetLogFilePath()
+// FindLogEntryByID returns the most recent received entry for id that still has
+// its payload.
+func FindLogEntryByID(id string, opts ReadOptions) (*LogEntry, error) {
+	if !opts.CurrentOnly {
+		store, err := OpenStore()
+		if err == nil {
+			defer store.Close()
+
+			entry, err := store.LatestWithPayload(context.Background(), id)
+			if err != nil {
+				return nil, err
+			}
+			if entry == nil {
+				return nil, fmt.Errorf("event with ID %s not found in local logs", id)
+			}
+			return entry, nil
+		}
+		slog.Debug("event store unavailable, reading the log files", "error", err)
+	}
+
+	files, err := transactionLogFiles(opts.CurrentOnly)
 	if err != nil {
 		return nil, err
 	}
 
-	file, err := os.Open(logPath)
+	var found *LogEntry
+	err = scanLogFiles(files, func(entry LogEntry) {
+		if entry.ID != id || entry.RawMessage == "" {
+			return
+		}
+		if found == nil || !entry.ParsedTime().Before(found.ParsedTime()) {
+			found = &entry
+		}
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to open log file: %w", err)
+		return nil, err
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
+	if found != nil {
+		return found, nil
+	}
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			continue
+	return nil, fmt.Errorf("event with ID %s not found in local logs", id)
+}
+
+func transactionLogFiles(currentOnly bool) ([]string, error) {
+	if currentOnly {
+		return TransactionLogPaths()
+	}
+	return AllLogFiles()
+}
+
+// sortEntries orders entries read from several files by time.
+func sortEntries(entries []LogEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].ParsedTime().Before(entries[j].ParsedTime())
+	})
+}
+
+// scanLogFiles calls fn for every entry of files, in order. Missing files are
+// skipped.
+func scanLogFiles(files []string, fn func(LogEntry)) error {
+	for _, path := range files {
+		r, err := openLogFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("failed to open log file: %w", err)
 		}
 
-		var entry LogEntry
-		if err := json.Unmarshal([]byte(line), &entry); err != nil {
-			slog.Debug("skipped malformed log entry", "error", err)
-			continue
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if len(line) == 0 {
+				continue
+			}
+
+			var entry LogEntry
+			if err := json.Unmarshal(line, &entry); err != nil {
+				slog.Debug("skipped malformed log entry", "error", err)
+				continue
+			}
+			fn(entry)
 		}
 
-		if entry.ID == id {
-			return &entry, nil
+		err = scanner.Err()
+		r.Close()
+		if err != nil {
+			return fmt.Errorf("error reading log file %s: %w", path, err)
 		}
 	}
-
-	return nil, fmt.Errorf("event with ID %s not found in local logs", id)
+	return nil
 }
@@ -0,0 +1,160 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"abacatepay-cli/internal/payload"
+)
+
+const redactedValue = "[REDACTED]"
+
+// redactedAttrs are the attributes of the transaction log that can carry
+// customer data.
+var redactedAttrs = map[string]bool{
+	"raw_message":   true,
+	"response_body": true,
+}
+
+// redactHandler masks PII fields inside the JSON payloads of a record before
+// passing it on.
+type redactHandler struct {
+	next   slog.Handler
+	fields map[string]bool
+}
+
+func newRedactHandler(next slog.Handler, fields []string) *redactHandler {
+	set := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		set[strings.ToLower(f)] = true
+	}
+	return &redactHandler{next: next, fields: set}
+}
+
+func (h *redactHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *redactHandler) Handle(ctx context.Context, r slog.Record) error {
+	redacted := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(h.redactAttr(a))
+		return true
+	})
+	return h.next.Handle(ctx, redacted)
+}
+
+func (h *redactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = h.redactAttr(a)
+	}
+	return &redactHandler{next: h.next.WithAttrs(redacted), fields: h.fields}
+}
+
+func (h *redactHandler) WithGroup(name string) slog.Handler {
+	return &redactHandler{next: h.next.WithGroup(name), fields: h.fields}
+}
+
+func (h *redactHandler) redactAttr(a slog.Attr) slog.Attr {
+	if !redactedAttrs[a.Key] || a.Value.Kind() != slog.KindString {
+		return a
+	}
+	return slog.String(a.Key, redactJSON(a.Value.String(), h.fields))
+}
+
+// RedactJSON masks the fields of a JSON document whose names are in fields,
+// at any depth. Text that isn't a JSON object is returned unchanged.
+func RedactJSON(raw string, fields []string) string {
+	set := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		set[strings.ToLower(f)] = true
+	}
+	return redactJSON(raw, set)
+}
+
+func redactJSON(raw string, fields map[string]bool) string {
+	doc, err := payload.Decode([]byte(raw))
+	if err != nil {
+		return raw
+	}
+
+	if !redactValue(doc, fields) {
+		return raw
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return raw
+	}
+	return string(data)
+}
+
+// redactValue masks matching fields in place and reports whether any was found.
+func redactValue(v any, fields map[string]bool) bool {
+	changed := false
+
+	switch node := v.(type) {
+	case map[string]any:
+		for key, child := range node {
+			if fields[strings.ToLower(key)] && child != nil {
+				node[key] = redactedValue
+				changed = true
+				continue
+			}
+			changed = redactValue(child, fields) || changed
+		}
+	case []any:
+		for _, child := range node {
+			changed = redactValue(child, fields) || changed
+		}
+	}
+	return changed
+}
+
+// RedactedPaths returns the dotted paths (as accepted by --set) of the fields of
+// a JSON payload that were masked by redaction, sorted. Such payloads no longer
+// hold the original values, so resending them as is would send the mask.
+func RedactedPaths(raw string) []string {
+	if !strings.Contains(raw, redactedValue) {
+		return nil
+	}
+
+	doc, err := payload.Decode([]byte(raw))
+	if err != nil {
+		return nil
+	}
+
+	var paths []string
+	collectRedacted(doc, "", &paths)
+	sort.Strings(paths)
+	return paths
+}
+
+func collectRedacted(v any, prefix string, paths *[]string) {
+	switch node := v.(type) {
+	case map[string]any:
+		for key, child := range node {
+			collectRedacted(child, joinPath(prefix, key), paths)
+		}
+	case []any:
+		for i, child := range node {
+			collectRedacted(child, joinPath(prefix, strconv.Itoa(i)), paths)
+		}
+	case string:
+		if node == redactedValue {
+			*paths = append(*paths, prefix)
+		}
+	}
+}
+
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRedactHandler_MasksPayloadFields(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(newRedactHandler(slog.NewJSONHandler(&buf, nil), DefaultRedactFields))
+
+	log.Info(MsgReceived,
+		"id", "evt_1",
+		"raw_message", `{"data":{"customer":{"name":"Ana","email":"ana@example.com","taxId":"123.456.789-00","cellphone":null}}}`,
+	)
+
+	var entry LogEntry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(entry.RawMessage, "ana@example.com") || strings.Contains(entry.RawMessage, "123.456.789-00") {
+		t.Fatalf("expected PII to be masked, got %s", entry.RawMessage)
+	}
+	if !strings.Contains(entry.RawMessage, `"name":"Ana"`) || !strings.Contains(entry.RawMessage, `"cellphone":null`) {
+		t.Fatalf("expected other fields to be kept, got %s", entry.RawMessage)
+	}
+	if entry.ID != "evt_1" {
+		t.Fatalf("expected the ID to be kept, got %q", entry.ID)
+	}
+}
+
+func TestLoadSettings_FailsClosed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), settingsFileName)
+	if err := os.WriteFile(path, []byte(`{"redact": true,`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := loadSettings(path)
+	if err == nil {
+		t.Fatal("expected an error for a malformed settings file")
+	}
+	if !settings.Redact || len(settings.RedactFields) == 0 {
+		t.Fatalf("expected redaction to stay on, got %+v", settings)
+	}
+}
+
+func TestRedactedPaths(t *testing.T) {
+	raw := RedactJSON(`{"data":{"customer":{"name":"Ana","email":"ana@example.com"},"payers":[{"taxId":"123"},{"taxId":null}]}}`, DefaultRedactFields)
+
+	got := RedactedPaths(raw)
+	want := []string{"data.customer.email", "data.payers.0.taxId"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	if got := RedactedPaths(`{"data":{"customer":{"email":"ana@example.com"}}}`); len(got) != 0 {
+		t.Fatalf("expected no redacted paths in a clear payload, got %v", got)
+	}
+}
@@ -0,0 +1,107 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const settingsFileName = "settings.json"
+
+// DefaultRedactFields are the payload fields masked when redaction is on.
+var DefaultRedactFields = []string{"taxId", "email", "cellphone"}
+
+// Settings control what the transaction log keeps. They are stored next to the
+// logs in settings.json.
+type Settings struct {
+	// RetentionDays is how long entries are kept. 0 keeps them forever.
+	RetentionDays int `json:"retentionDays"`
+	// Redact masks RedactFields in payloads and responses before they are
+	// written, so they never reach the disk.
+	Redact       bool     `json:"redact"`
+	RedactFields []string `json:"redactFields"`
+	// SeparateProfiles writes the events of each profile to its own file in
+	// profiles/<name>/. They are still listed and searched together.
+	SeparateProfiles bool `json:"separateProfiles"`
+}
+
+func DefaultSettings() Settings {
+	return Settings{
+		RetentionDays: 30,
+		RedactFields:  DefaultRedactFields,
+	}
+}
+
+func GetSettingsPath() (string, error) {
+	cfg, err := DefaultConfig()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cfg.LogDir, settingsFileName), nil
+}
+
+func LoadSettings() (Settings, error) {
+	path, err := GetSettingsPath()
+	if err != nil {
+		return DefaultSettings(), err
+	}
+	return loadSettings(path)
+}
+
+// loadSettings reads the settings at path. When the file exists but can't be
+// read or parsed, the defaults are returned with redaction on: the file may
+// have asked for it, and PII must not be written in that case.
+func loadSettings(path string) (Settings, error) {
+	settings := DefaultSettings()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return settings, nil
+		}
+		return failClosedSettings(), fmt.Errorf("failed to read log settings: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return failClosedSettings(), fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return settings, nil
+}
+
+func failClosedSettings() Settings {
+	settings := DefaultSettings()
+	settings.Redact = true
+	return settings
+}
+
+func SaveSettings(settings Settings) error {
+	path, err := GetSettingsPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write log settings: %w", err)
+	}
+	return nil
+}
+
+// RetentionCutoff is the time before which entries should be removed, or the
+// zero time when they are kept forever.
+func (s Settings) RetentionCutoff(now time.Time) time.Time {
+	if s.RetentionDays <= 0 {
+		return time.Time{}
+	}
+	return now.AddDate(0, 0, -s.RetentionDays)
+}
@@ -0,0 +1,201 @@
+package logger
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"abacatepay-cli/internal/stats"
+)
+
+// DeliveryStats summarizes a set of deliveries and their forward attempts.
+type DeliveryStats struct {
+	Deliveries  int
+	Attempts    int
+	Succeeded   int
+	Failed      int
+	Latency     stats.Latency
+	Events      []EventTypeStats
+	Targets     []TargetStats
+	Slowest     []ForwardStat
+	MostFailing []FailingEvent
+}
+
+type EventTypeStats struct {
+	Event      string
+	Deliveries int
+	Delivered  int
+	Failed     int
+	Pending    int
+}
+
+type TargetStats struct {
+	URL       string
+	Attempts  int
+	Succeeded int
+	Failed    int
+	Latency   stats.Latency
+}
+
+func (t TargetStats) SuccessRate() float64 {
+	return rate(t.Succeeded, t.Attempts)
+}
+
+// ForwardStat is a single forward attempt.
+type ForwardStat struct {
+	EventID    string
+	Event      string
+	URL        string
+	Time       time.Time
+	StatusCode int
+	Duration   time.Duration
+	Error      string
+}
+
+type FailingEvent struct {
+	EventID   string
+	Event     string
+	Attempts  int
+	Failures  int
+	LastError string
+}
+
+func (s DeliveryStats) SuccessRate() float64 {
+	return rate(s.Succeeded, s.Attempts)
+}
+
+func rate(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
+
+// SummarizeDeliveries counts deliveries per event type and forward attempts
+// per target, with their latency. Slowest and MostFailing hold at most top
+// entries each.
+func SummarizeDeliveries(deliveries []LoggedDelivery, top int) DeliveryStats {
+	var (
+		s         = DeliveryStats{Deliveries: len(deliveries)}
+		latencies []time.Duration
+		forwards  []ForwardStat
+	)
+
+	events := map[string]*EventTypeStats{}
+	targets := map[string]*TargetStats{}
+	targetLatencies := map[string][]time.Duration{}
+	failing := map[string]*FailingEvent{}
+
+	for _, d := range deliveries {
+		if d.Event == "" {
+			d.Event = "unknown"
+		}
+
+		et := events[d.Event]
+		if et == nil {
+			et = &EventTypeStats{Event: d.Event}
+			events[d.Event] = et
+		}
+		et.Deliveries++
+		switch d.Status {
+		case StatusDelivered:
+			et.Delivered++
+		case StatusFailed:
+			et.Failed++
+		default:
+			et.Pending++
+		}
+
+		for _, entry := range d.Entries {
+			if !isForwardMsg(entry.Msg) {
+				continue
+			}
+
+			f := ForwardStat{
+				EventID:    entry.ID,
+				Event:      d.Event,
+				URL:        entry.URL,
+				Time:       entry.ParsedTime(),
+				StatusCode: entry.StatusCode,
+				Duration:   time.Duration(entry.DurationMs) * time.Millisecond,
+				Error:      entry.Error,
+			}
+			forwards = append(forwards, f)
+			latencies = append(latencies, f.Duration)
+			targetLatencies[f.URL] = append(targetLatencies[f.URL], f.Duration)
+
+			t := targets[f.URL]
+			if t == nil {
+				t = &TargetStats{URL: f.URL}
+				targets[f.URL] = t
+			}
+
+			fe := failing[f.EventID]
+			if fe == nil {
+				fe = &FailingEvent{EventID: f.EventID, Event: f.Event}
+				failing[f.EventID] = fe
+			}
+
+			s.Attempts++
+			t.Attempts++
+			fe.Attempts++
+			if entry.Msg == MsgForwarded {
+				s.Succeeded++
+				t.Succeeded++
+				continue
+			}
+
+			s.Failed++
+			t.Failed++
+			fe.Failures++
+			fe.LastError = f.Error
+			if fe.LastError == "" {
+				fe.LastError = fmt.Sprintf("%d %s", f.StatusCode, http.StatusText(f.StatusCode))
+			}
+		}
+	}
+
+	s.Latency = stats.Summarize(latencies)
+
+	for _, et := range events {
+		s.Events = append(s.Events, *et)
+	}
+	sort.Slice(s.Events, func(i, j int) bool {
+		if s.Events[i].Deliveries != s.Events[j].Deliveries {
+			return s.Events[i].Deliveries > s.Events[j].Deliveries
+		}
+		return s.Events[i].Event < s.Events[j].Event
+	})
+
+	for url, t := range targets {
+		t.Latency = stats.Summarize(targetLatencies[url])
+		s.Targets = append(s.Targets, *t)
+	}
+	sort.Slice(s.Targets, func(i, j int) bool {
+		if s.Targets[i].Attempts != s.Targets[j].Attempts {
+			return s.Targets[i].Attempts > s.Targets[j].Attempts
+		}
+		return s.Targets[i].URL < s.Targets[j].URL
+	})
+
+	sort.SliceStable(forwards, func(i, j int) bool {
+		return forwards[i].Duration > forwards[j].Duration
+	})
+	s.Slowest = forwards[:min(top, len(forwards))]
+
+	for _, fe := range failing {
+		if fe.Failures > 0 {
+			s.MostFailing = append(s.MostFailing, *fe)
+		}
+	}
+	sort.Slice(s.MostFailing, func(i, j int) bool {
+		if s.MostFailing[i].Failures != s.MostFailing[j].Failures {
+			return s.MostFailing[i].Failures > s.MostFailing[j].Failures
+		}
+		return s.MostFailing[i].EventID < s.MostFailing[j].EventID
+	})
+	s.MostFailing = s.MostFailing[:min(top, len(s.MostFailing))]
+
+	return s
+}
@@ -0,0 +1,38 @@
+package logger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarizeDeliveries(t *testing.T) {
+	entries := []LogEntry{
+		{Msg: MsgReceived, ID: "evt_1", DeliveryID: "dlv_1", Event: "billing.paid", Time: "2026-01-01T10:00:00Z"},
+		{Msg: MsgForwarded, ID: "evt_1", DeliveryID: "dlv_1", URL: "http://a", StatusCode: 200, DurationMs: 10, Time: "2026-01-01T10:00:01Z"},
+		{Msg: MsgReceived, ID: "evt_2", DeliveryID: "dlv_2", Event: "billing.paid", Time: "2026-01-01T10:01:00Z"},
+		{Msg: MsgForwardError, ID: "evt_2", DeliveryID: "dlv_2", URL: "http://a", StatusCode: 500, DurationMs: 300, Time: "2026-01-01T10:01:01Z"},
+		{Msg: MsgForwardFailed, ID: "evt_2", DeliveryID: "dlv_2", URL: "http://b", DurationMs: 50, Error: "connection refused", Time: "2026-01-01T10:01:01Z"},
+		{Msg: MsgReceived, ID: "evt_3", DeliveryID: "dlv_3", Event: "payout.done", Time: "2026-01-01T10:02:00Z"},
+	}
+
+	s := SummarizeDeliveries(GroupDeliveries(entries), 1)
+
+	if s.Deliveries != 3 || s.Attempts != 3 || s.Succeeded != 1 || s.Failed != 2 {
+		t.Fatalf("unexpected totals: %+v", s)
+	}
+	if len(s.Events) != 2 || s.Events[0].Event != "billing.paid" || s.Events[0].Failed != 1 || s.Events[1].Pending != 1 {
+		t.Fatalf("unexpected event counts: %+v", s.Events)
+	}
+	if len(s.Targets) != 2 || s.Targets[0].URL != "http://a" || s.Targets[0].SuccessRate() != 0.5 {
+		t.Fatalf("unexpected targets: %+v", s.Targets)
+	}
+	if len(s.Slowest) != 1 || s.Slowest[0].Duration != 300*time.Millisecond {
+		t.Fatalf("unexpected slowest: %+v", s.Slowest)
+	}
+	if len(s.MostFailing) != 1 || s.MostFailing[0].EventID != "evt_2" || s.MostFailing[0].Failures != 2 || s.MostFailing[0].LastError != "connection refused" {
+		t.Fatalf("unexpected most failing: %+v", s.MostFailing)
+	}
+	if s.Latency.P99 != 300*time.Millisecond {
+		t.Fatalf("unexpected latency: %+v", s.Latency)
+	}
+}
@@ -0,0 +1,406 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const storeFileName = "events.db"
+
+// storeVersion is stored as the user_version of the database. Opening a store
+// at this version skips creating the schema and importing the log files.
+//
+//  1. entries and the import of the log files
+//  2. the delivery_id column
+const storeVersion = 2
+
+const storeSchema = `
+CREATE TABLE IF NOT EXISTS entries (
+	seq         INTEGER PRIMARY KEY AUTOINCREMENT,
+	hash        TEXT    NOT NULL UNIQUE,
+	time        INTEGER NOT NULL,
+	msg         TEXT    NOT NULL,
+	event_id    TEXT    NOT NULL DEFAULT '',
+	delivery_id TEXT    NOT NULL DEFAULT '',
+	event       TEXT    NOT NULL DEFAULT '',
+	has_payload INTEGER NOT NULL DEFAULT 0,
+	data        TEXT    NOT NULL
+);
+CREATE INDEX IF NOT EXISTS entries_time ON entries (time, seq);
+CREATE INDEX IF NOT EXISTS entries_event_id ON entries (event_id, time);
+CREATE INDEX IF NOT EXISTS entries_msg ON entries (msg, time);
+
+CREATE TABLE IF NOT EXISTS meta (
+	key   TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+`
+
+// Store is the indexed copy of the transaction log. Every line is written to
+// both on purpose: transactions.log stays the source of truth, readable with
+// any tool and rotated by lumberjack, while the store only makes queries fast
+// and can be rebuilt from the files at any time with 'logs import'. Lines are
+// keyed by a hash, so imports can run any number of times without duplicates.
+type Store struct {
+	db *sql.DB
+}
+
+func GetStorePath() (string, error) {
+	cfg, err := DefaultConfig()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cfg.LogDir, storeFileName), nil
+}
+
+// OpenStore opens the store in the default location, importing the existing
+// transaction log the first time.
+func OpenStore() (*Store, error) {
+	cfg, err := DefaultConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return openStoreIn(cfg.LogDir)
+}
+
+func openStoreIn(logDir string) (*Store, error) {
+	if err := os.MkdirAll(logDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	s, err := openStore(filepath.Join(logDir, storeFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	var version int
+	if err := s.db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		s.Close()
+		return nil, fmt.Errorf("failed to read event store version: %w", err)
+	}
+	if version >= storeVersion {
+		return s, nil
+	}
+
+	if err := s.migrate(logDir, version); err != nil {
+		s.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func openStore(path string) (*Store, error) {
+	dsn := "file:" + path + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=synchronous(NORMAL)"
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event store: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// migrate creates the schema and imports the log files written before the
+// store existed, including rotated backups, then upgrades a store created at
+// an older version.
+func (s *Store) migrate(logDir string, version int) error {
+	if _, err := s.db.Exec(storeSchema); err != nil {
+		return fmt.Errorf("failed to initialize event store: %w", err)
+	}
+
+	if version < 2 {
+		if err := s.addDeliveryIDColumn(); err != nil {
+			return err
+		}
+	}
+
+	var done string
+	err := s.db.QueryRow(`SELECT value FROM meta WHERE key = 'log_files_imported'`).Scan(&done)
+	if err == sql.ErrNoRows {
+		if err := s.importLogFiles(logDir); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to read event store metadata: %w", err)
+	}
+
+	if _, err := s.db.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, storeVersion)); err != nil {
+		return fmt.Errorf("failed to update event store version: %w", err)
+	}
+	return nil
+}
+
+// addDeliveryIDColumn adds the delivery_id column to a version 1 store and
+// fills it from the stored lines.
+func (s *Store) addDeliveryIDColumn() error {
+	var exists int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('entries') WHERE name = 'delivery_id'`).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to read event store schema: %w", err)
+	}
+
+	stmts := []string{
+		`UPDATE entries SET delivery_id = COALESCE(json_extract(data, '$.delivery_id'), '') WHERE delivery_id = ''`,
+		`CREATE INDEX IF NOT EXISTS entries_delivery_id ON entries (delivery_id, time)`,
+	}
+	if exists == 0 {
+		stmts = append([]string{`ALTER TABLE entries ADD COLUMN delivery_id TEXT NOT NULL DEFAULT ''`}, stmts...)
+	}
+
+	for _, stmt := range stmts {
+		if _, err := s.db.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to upgrade event store: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *Store) importLogFiles(logDir string) error {
+	files, err := LogFiles(filepath.Join(logDir, "transactions.log"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if _, err := s.ImportFile(file); err != nil {
+			return err
+		}
+	}
+
+	_, err = s.db.Exec(`INSERT OR REPLACE INTO meta (key, value) VALUES ('log_files_imported', ?)`, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("failed to update event store metadata: %w", err)
+	}
+	return nil
+}
+
+// InsertLine stores one JSON line of the transaction log. It reports whether
+// the line was new.
+func (s *Store) InsertLine(ctx context.Context, line []byte) (bool, error) {
+	return insertLine(ctx, s.db, line)
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func insertLine(ctx context.Context, db execer, line []byte) (bool, error) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 {
+		return false, nil
+	}
+
+	var entry LogEntry
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return false, fmt.Errorf("invalid log entry: %w", err)
+	}
+
+	sum := sha256.Sum256(line)
+
+	hasPayload := 0
+	if entry.RawMessage != "" {
+		hasPayload = 1
+	}
+
+	res, err := db.ExecContext(ctx,
+		`INSERT OR IGNORE INTO entries (hash, time, msg, event_id, delivery_id, event, has_payload, data) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		hex.EncodeToString(sum[:]), entry.ParsedTime().UnixNano(), entry.Msg, entry.ID, entry.DeliveryID, entry.Event, hasPayload, string(line),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to store log entry: %w", err)
+	}
+
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+type storeQuery struct {
+	where []string
+	args  []any
+}
+
+func (q *storeQuery) add(cond string, args ...any) {
+	q.where = append(q.where, cond)
+	q.args = append(q.args, args...)
+}
+
+func (q *storeQuery) clause() string {
+	if len(q.where) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(q.where, " AND ")
+}
+
+func (q *storeQuery) addReadOptions(opts ReadOptions) {
+	if opts.TypeFilter != "" {
+		q.add("msg = ?", opts.TypeFilter)
+	}
+	if !opts.Since.IsZero() {
+		q.add("time >= ?", opts.Since.UnixNano())
+	}
+	if !opts.Until.IsZero() {
+		q.add("time <= ?", opts.Until.UnixNano())
+	}
+}
+
+// addEntryFilters adds the filters of lq that apply to each entry on its own,
+// everything but the payload filters.
+func (q *storeQuery) addEntryFilters(lq Query) {
+	if lq.Profile != "" {
+		q.add("json_extract(data, '$.profile') = ?", lq.Profile)
+	}
+	if lq.Env != "" {
+		q.add("json_extract(data, '$.env') = ?", lq.Env)
+	}
+	if len(lq.Events) > 0 {
+		q.add("event IN (?"+strings.Repeat(", ?", len(lq.Events)-1)+")", anySlice(lq.Events)...)
+	}
+	if lq.StatusCodes != nil {
+		q.add("json_extract(data, '$.status_code') BETWEEN ? AND ?", lq.StatusCodes.Min, lq.StatusCodes.Max)
+	}
+	if lq.URL != "" {
+		q.add("instr(COALESCE(json_extract(data, '$.url'), ''), ?) > 0", lq.URL)
+	}
+	if lq.MinDuration > 0 {
+		q.add("COALESCE(json_extract(data, '$.duration_ms'), 0) * 1000000 >= ?", lq.MinDuration.Nanoseconds())
+	}
+}
+
+func anySlice(values []string) []any {
+	args := make([]any, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return args
+}
+
+// Entries returns the entries matching opts in chronological order. With a
+// limit, the most recent entries are returned.
+func (s *Store) Entries(ctx context.Context, opts ReadOptions) ([]LogEntry, error) {
+	return s.Search(ctx, Query{}, opts)
+}
+
+// Search returns the entries matching q and opts in chronological order. With
+// a limit, the most recent entries are returned. q must not filter on the
+// payload.
+func (s *Store) Search(ctx context.Context, q Query, opts ReadOptions) ([]LogEntry, error) {
+	var sq storeQuery
+	sq.addReadOptions(opts)
+	sq.addEntryFilters(q)
+
+	query := "SELECT data FROM entries" + sq.clause() + " ORDER BY time DESC, seq DESC"
+	if opts.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", opts.Limit)
+	}
+
+	entries, err := s.query(ctx, query, sq.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Reverse(entries)
+	return entries, nil
+}
+
+// LatestWithPayload returns the most recent entry of an event that still has
+// its payload, or nil.
+func (s *Store) LatestWithPayload(ctx context.Context, id string) (*LogEntry, error) {
+	entries, err := s.query(ctx,
+		`SELECT data FROM entries WHERE event_id = ? AND has_payload = 1 ORDER BY time DESC, seq DESC LIMIT 1`, id)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+	return &entries[0], nil
+}
+
+// EntriesFor returns the entries of an event or of a single delivery, in
+// chronological order.
+func (s *Store) EntriesFor(ctx context.Context, id string) ([]LogEntry, error) {
+	return s.query(ctx,
+		`SELECT data FROM entries WHERE event_id = ? OR delivery_id = ? ORDER BY time, seq`, id, id)
+}
+
+// DeliveryEntries returns every entry of the deliveries with at least one
+// entry matching q and opts, in chronological order. With a limit, only the
+// entries of the most recent deliveries are returned. q must not filter on
+// the payload. Entries logged before delivery IDs are selected by event ID,
+// so they can include deliveries that don't match.
+func (s *Store) DeliveryEntries(ctx context.Context, q Query, opts ReadOptions) ([]LogEntry, error) {
+	var sq storeQuery
+	sq.addReadOptions(opts)
+	sq.addEntryFilters(q)
+
+	selected := `SELECT delivery_id, CASE WHEN delivery_id = '' THEN event_id ELSE '' END AS legacy_id, MIN(time) AS started
+		FROM entries` + sq.clause() + ` GROUP BY delivery_id, legacy_id ORDER BY started DESC`
+	if opts.Limit > 0 {
+		selected += fmt.Sprintf(" LIMIT %d", opts.Limit)
+	}
+
+	query := `WITH selected AS (` + selected + `)
+		SELECT data FROM entries
+		WHERE delivery_id IN (SELECT delivery_id FROM selected WHERE delivery_id != '')
+			OR (delivery_id = '' AND event_id IN (SELECT legacy_id FROM selected WHERE legacy_id != ''))
+		ORDER BY time, seq`
+
+	return s.query(ctx, query, sq.args...)
+}
+
+// Purge deletes the entries matching opts and returns how many were removed.
+func (s *Store) Purge(ctx context.Context, opts PurgeOptions) (int64, error) {
+	var q storeQuery
+	if !opts.Before.IsZero() {
+		q.add("time < ?", opts.Before.UnixNano())
+	}
+	if opts.Profile != "" {
+		q.add("json_extract(data, '$.profile') = ?", opts.Profile)
+	}
+
+	res, err := s.db.ExecContext(ctx, "DELETE FROM entries"+q.clause(), q.args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge event store: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+func (s *Store) query(ctx context.Context, query string, args ...any) ([]LogEntry, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query event store: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []LogEntry
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to read event store: %w", err)
+		}
+
+		var entry LogEntry
+		if err := json.Unmarshal([]byte(data), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read event store: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// storeHandler renders records exactly like the JSON handler of the
+// transaction log file and inserts them into the store, so the same line
+// imported later from the file is recognized as a duplicate.
+type storeHandler struct {
+	store *Store
+	json  slog.Handler
+	buf   *bytes.Buffer
+	mu    *sync.Mutex
+}
+
+func newStoreHandler(store *Store, opts *slog.HandlerOptions) *storeHandler {
+	buf := &bytes.Buffer{}
+	return &storeHandler{
+		store: store,
+		json:  slog.NewJSONHandler(buf, opts),
+		buf:   buf,
+		mu:    &sync.Mutex{},
+	}
+}
+
+func (h *storeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.json.Enabled(ctx, level)
+}
+
+func (h *storeHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.mu.Lock()
+	h.buf.Reset()
+	err := h.json.Handle(ctx, r)
+	line := bytes.Clone(h.buf.Bytes())
+	h.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.store.InsertLine(ctx, line); err != nil {
+		slog.Debug("failed to write to event store", "error", err)
+	}
+	return nil
+}
+
+func (h *storeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &storeHandler{store: h.store, json: h.json.WithAttrs(attrs), buf: h.buf, mu: h.mu}
+}
+
+func (h *storeHandler) WithGroup(name string) slog.Handler {
+	return &storeHandler{store: h.store, json: h.json.WithGroup(name), buf: h.buf, mu: h.mu}
+}
@@ -0,0 +1,274 @@
+package logger
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStore_ImportIsIdempotent(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "transactions.log")
+
+	lines := `{"time":"2026-01-01T10:00:00Z","level":"INFO","msg":"webhook_received","id":"evt_1","event":"billing.paid","raw_message":"{\"v\":1}"}
+{"time":"2026-01-01T10:00:01Z","level":"INFO","msg":"webhook_forwarded","id":"evt_1","event":"billing.paid","status_code":200}
+not json
+{"time":"2026-01-01T11:00:00Z","level":"INFO","msg":"webhook_received","id":"evt_1","event":"billing.paid","raw_message":"{\"v\":2}"}
+`
+	if err := os.WriteFile(logPath, []byte(lines), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := openStoreIn(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	result, err := store.ImportFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Imported != 0 || result.Skipped != 1 {
+		t.Fatalf("expected the migration to have imported everything, got %+v", result)
+	}
+
+	ctx := context.Background()
+
+	entries, err := store.Entries(ctx, ReadOptions{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[1].Time != "2026-01-01T11:00:00Z" {
+		t.Fatalf("expected the 2 latest entries in order, got %+v", entries)
+	}
+
+	latest, err := store.LatestWithPayload(ctx, "evt_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest == nil || latest.RawMessage != `{"v":2}` {
+		t.Fatalf("expected the latest payload, got %+v", latest)
+	}
+}
+
+func TestStore_MigratesOnce(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := openStoreIn(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	// A log written after the first open must not be imported on reopen.
+	line := `{"time":"2026-01-01T10:00:00Z","level":"INFO","msg":"webhook_received","id":"evt_1","event":"billing.paid"}` + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "transactions.log"), []byte(line), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = openStoreIn(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	var version int
+	if err := store.db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil || version != storeVersion {
+		t.Fatalf("expected version %d, got %d (%v)", storeVersion, version, err)
+	}
+
+	entries, err := store.Entries(context.Background(), ReadOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected no entries imported on reopen, got %+v", entries)
+	}
+}
+
+const deliveryLines = `{"time":"2026-01-01T10:00:00Z","msg":"webhook_received","id":"evt_1","delivery_id":"dlv_1","event":"billing.paid"}
+{"time":"2026-01-01T10:00:01Z","msg":"webhook_forward_error","id":"evt_1","delivery_id":"dlv_1","event":"billing.paid","status_code":500,"duration_ms":900}
+{"time":"2026-01-01T11:00:00Z","msg":"webhook_received","id":"evt_1","delivery_id":"dlv_2","event":"billing.paid"}
+{"time":"2026-01-01T11:00:01Z","msg":"webhook_forwarded","id":"evt_1","delivery_id":"dlv_2","event":"billing.paid","status_code":200,"duration_ms":20}
+{"time":"2026-01-01T12:00:00Z","msg":"webhook_received","id":"evt_2","event":"billing.refunded"}
+{"time":"2026-01-01T12:00:01Z","msg":"webhook_forward_error","id":"evt_2","event":"billing.refunded","status_code":502,"duration_ms":30}
+{"time":"2026-01-01T13:00:00Z","msg":"webhook_received","id":"evt_3","delivery_id":"dlv_3","event":"billing.paid"}
+{"time":"2026-01-01T13:00:01Z","msg":"webhook_forward_error","id":"evt_3","delivery_id":"dlv_3","event":"billing.paid","status_code":503,"duration_ms":1200}
+`
+
+func TestStore_DeliveryEntries(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "transactions.log"), []byte(deliveryLines), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := openStoreIn(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	deliveryIDs := func(q Query, opts ReadOptions) []string {
+		t.Helper()
+		entries, err := store.DeliveryEntries(ctx, q, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, d := range GroupDeliveries(entries) {
+			ids = append(ids, d.ID+"/"+d.EventID+"/"+strconv.Itoa(len(d.Entries)))
+		}
+		return ids
+	}
+
+	codes := &StatusRange{Min: 500, Max: 599}
+
+	if got := deliveryIDs(Query{StatusCodes: codes}, ReadOptions{}); !slices.Equal(got, []string{"dlv_1/evt_1/2", "/evt_2/2", "dlv_3/evt_3/2"}) {
+		t.Fatalf("expected every entry of the failed deliveries, got %v", got)
+	}
+	if got := deliveryIDs(Query{StatusCodes: codes}, ReadOptions{Limit: 2}); !slices.Equal(got, []string{"/evt_2/2", "dlv_3/evt_3/2"}) {
+		t.Fatalf("expected the 2 latest failed deliveries, got %v", got)
+	}
+	if got := deliveryIDs(Query{Events: []string{"billing.paid"}, MinDuration: time.Second}, ReadOptions{}); !slices.Equal(got, []string{"dlv_3/evt_3/2"}) {
+		t.Fatalf("expected the slow billing.paid delivery, got %v", got)
+	}
+	if got := deliveryIDs(Query{}, ReadOptions{TypeFilter: MsgForwarded}); !slices.Equal(got, []string{"dlv_2/evt_1/2"}) {
+		t.Fatalf("expected the delivered delivery, got %v", got)
+	}
+	since := time.Date(2026, 1, 1, 10, 30, 0, 0, time.UTC)
+	if got := deliveryIDs(Query{}, ReadOptions{Since: since, Limit: 1}); !slices.Equal(got, []string{"dlv_3/evt_3/2"}) {
+		t.Fatalf("expected the latest delivery, got %v", got)
+	}
+
+	entries, err := store.EntriesFor(ctx, "dlv_2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[1].StatusCode != 200 {
+		t.Fatalf("expected the entries of dlv_2, got %+v", entries)
+	}
+}
+
+func TestStore_UpgradesVersion1(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := openStore(filepath.Join(dir, storeFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v1 := `
+CREATE TABLE entries (
+	seq         INTEGER PRIMARY KEY AUTOINCREMENT,
+	hash        TEXT    NOT NULL UNIQUE,
+	time        INTEGER NOT NULL,
+	msg         TEXT    NOT NULL,
+	event_id    TEXT    NOT NULL DEFAULT '',
+	event       TEXT    NOT NULL DEFAULT '',
+	has_payload INTEGER NOT NULL DEFAULT 0,
+	data        TEXT    NOT NULL
+);
+CREATE TABLE meta (key TEXT PRIMARY KEY, value TEXT NOT NULL);
+INSERT INTO meta VALUES ('log_files_imported', '2026-01-01T00:00:00Z');
+INSERT INTO entries (hash, time, msg, event_id, event, data) VALUES
+	('a', 1, 'webhook_received', 'evt_1', 'billing.paid', '{"msg":"webhook_received","id":"evt_1","delivery_id":"dlv_1"}'),
+	('b', 2, 'webhook_received', 'evt_2', 'billing.paid', '{"msg":"webhook_received","id":"evt_2"}');
+PRAGMA user_version = 1;
+`
+	if _, err := store.db.Exec(v1); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	store, err = openStoreIn(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	var version int
+	if err := store.db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil || version != storeVersion {
+		t.Fatalf("expected version %d, got %d (%v)", storeVersion, version, err)
+	}
+
+	entries, err := store.EntriesFor(context.Background(), "dlv_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].ID != "evt_1" {
+		t.Fatalf("expected the backfilled delivery, got %+v", entries)
+	}
+
+	var id, parent, unused int
+	var plan string
+	err = store.db.QueryRow(`EXPLAIN QUERY PLAN SELECT data FROM entries WHERE delivery_id = 'dlv_1'`).Scan(&id, &parent, &unused, &plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(plan, "entries_delivery_id") {
+		t.Fatalf("expected the delivery_id index to be used, got %q", plan)
+	}
+}
+
+func TestStore_Search(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "transactions.log"), []byte(deliveryLines), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := openStoreIn(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	search := func(q Query, opts ReadOptions) []string {
+		t.Helper()
+		entries, err := store.Search(ctx, q, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The store must agree with the in-memory filters.
+		all, err := store.Entries(ctx, ReadOptions{TypeFilter: opts.TypeFilter, Since: opts.Since, Until: opts.Until})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := q.Filter(all)
+		if opts.Limit > 0 && len(want) > opts.Limit {
+			want = want[len(want)-opts.Limit:]
+		}
+		if len(entries) != len(want) {
+			t.Fatalf("expected %d entries like the in-memory filter, got %d", len(want), len(entries))
+		}
+
+		var times []string
+		for i, e := range entries {
+			if e != want[i] {
+				t.Fatalf("entry %d: expected %+v, got %+v", i, want[i], e)
+			}
+			times = append(times, e.Time[11:16])
+		}
+		return times
+	}
+
+	if got := search(Query{Events: []string{"billing.paid"}}, ReadOptions{Limit: 3}); !slices.Equal(got, []string{"11:00", "13:00", "13:00"}) {
+		t.Fatalf("expected the 3 latest billing.paid entries, got %v", got)
+	}
+	if got := search(Query{StatusCodes: &StatusRange{Min: 500, Max: 502}}, ReadOptions{}); !slices.Equal(got, []string{"10:00", "12:00"}) {
+		t.Fatalf("expected the 500 and 502 forwards, got %v", got)
+	}
+	if got := search(Query{MinDuration: 900 * time.Millisecond}, ReadOptions{Until: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}); !slices.Equal(got, []string{"10:00"}) {
+		t.Fatalf("expected the slow forward before noon, got %v", got)
+	}
+	if got := search(Query{Events: []string{"billing.refunded", "billing.paid"}}, ReadOptions{TypeFilter: MsgReceived}); len(got) != 4 {
+		t.Fatalf("expected every received entry, got %v", got)
+	}
+}
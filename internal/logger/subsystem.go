@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+// SubsystemKey is the attribute naming the part of the CLI a line comes from.
+const SubsystemKey = "subsystem"
+
+// Subsystems are the names passed to Subsystem, whose levels can be set.
+var Subsystems = []string{"auth", "payments", "webhook", "ws"}
+
+// Subsystem returns the default logger tagged with a subsystem name, so its
+// level can be set on its own with --log-level (e.g. ws=debug). Call it where
+// you log instead of keeping it in a package variable, so it picks up the
+// logger configured at startup.
+func Subsystem(name string) *slog.Logger {
+	return slog.Default().With(SubsystemKey, name)
+}
+
+// ParseLevels parses a level spec such as "ws=debug,payments=info". An entry
+// without a subsystem sets the level of everything else, which starts at def.
+func ParseLevels(spec string, def slog.Level) (slog.Level, map[string]slog.Level, error) {
+	levels := map[string]slog.Level{}
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			name, value = "", part
+		}
+
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(strings.TrimSpace(value))); err != nil {
+			return def, nil, fmt.Errorf("invalid log level %q (valid: debug, info, warn, error)", value)
+		}
+
+		name = strings.TrimSpace(name)
+		if name == "" {
+			def = level
+			continue
+		}
+		if !slices.Contains(Subsystems, name) {
+			return def, nil, fmt.Errorf("unknown log subsystem %q (valid: %s)", name, strings.Join(Subsystems, ", "))
+		}
+		levels[name] = level
+	}
+
+	return def, levels, nil
+}
+
+// levelHandler filters records by the level of the subsystem of the logger,
+// falling back to the default level.
+type levelHandler struct {
+	next      slog.Handler
+	level     slog.Level
+	levels    map[string]slog.Level
+	subsystem string
+}
+
+func newLevelHandler(next slog.Handler, level slog.Level, levels map[string]slog.Level) *levelHandler {
+	return &levelHandler{next: next, level: level, levels: levels}
+}
+
+// minLevel is the lowest level any subsystem logs at, the level the wrapped
+// handlers need to let through.
+func minLevel(level slog.Level, levels map[string]slog.Level) slog.Level {
+	for _, l := range levels {
+		level = min(level, l)
+	}
+	return level
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	threshold := h.level
+	if l, ok := h.levels[h.subsystem]; ok {
+		threshold = l
+	}
+	return level >= threshold && h.next.Enabled(ctx, level)
+}
+
+func (h *levelHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.next.Handle(ctx, r)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	for _, a := range attrs {
+		if a.Key == SubsystemKey {
+			c.subsystem = a.Value.String()
+		}
+	}
+	c.next = h.next.WithAttrs(attrs)
+	return &c
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	c := *h
+	c.next = h.next.WithGroup(name)
+	return &c
+}
@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestParseLevels(t *testing.T) {
+	def, levels, err := ParseLevels("warn, ws=debug,payments=info", slog.LevelInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if def != slog.LevelWarn || levels["ws"] != slog.LevelDebug || levels["payments"] != slog.LevelInfo {
+		t.Fatalf("unexpected levels: %v %v", def, levels)
+	}
+
+	if _, _, err := ParseLevels("ws=loud", slog.LevelInfo); err == nil {
+		t.Fatal("expected an error for an unknown level")
+	}
+	if _, _, err := ParseLevels("websocket=debug", slog.LevelInfo); err == nil || !strings.Contains(err.Error(), "auth, payments, webhook, ws") {
+		t.Fatalf("expected an error listing the subsystems, got %v", err)
+	}
+}
+
+func TestSetupConsole_HonorsLevels(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+
+	cfg := &Config{Level: slog.LevelWarn, Levels: map[string]slog.Level{"ws": slog.LevelDebug}, Format: FormatJSON}
+	if _, err := SetupConsole(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if !Subsystem("ws").Enabled(ctx, slog.LevelDebug) {
+		t.Error("expected ws debug lines to be enabled")
+	}
+	if Subsystem("payments").Enabled(ctx, slog.LevelInfo) || slog.Default().Enabled(ctx, slog.LevelInfo) {
+		t.Error("expected info lines outside ws to be dropped")
+	}
+
+	if _, err := SetupConsole(&Config{Format: "xml"}); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestLevelHandler_PerSubsystem(t *testing.T) {
+	var buf bytes.Buffer
+	next := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	log := slog.New(newLevelHandler(next, slog.LevelInfo, map[string]slog.Level{"ws": slog.LevelDebug, "payments": slog.LevelWarn}))
+
+	log.With(SubsystemKey, "ws").Debug("reconnecting")
+	log.With(SubsystemKey, "payments").Info("request sent")
+	log.Debug("hidden")
+	log.Info("shown")
+
+	out := buf.String()
+	if !strings.Contains(out, "reconnecting") || !strings.Contains(out, "shown") {
+		t.Fatalf("expected ws debug and default info lines, got %q", out)
+	}
+	if strings.Contains(out, "request sent") || strings.Contains(out, "hidden") {
+		t.Fatalf("expected payments info and default debug lines to be dropped, got %q", out)
+	}
+}
@@ -0,0 +1,120 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// textHandler writes the lines read by people on stderr:
+//
+//	WARN  [ws] connection lost attempt=2 error="read: connection reset"
+//
+// The time is left out, as the lines are read as they're written, and the
+// subsystem comes before the message instead of among the attributes. Use the
+// logfmt or json format for lines parsed by other tools.
+type textHandler struct {
+	mu        *sync.Mutex
+	w         io.Writer
+	level     slog.Leveler
+	subsystem string
+	attrs     []byte
+	prefix    string
+}
+
+func newTextHandler(w io.Writer, level slog.Leveler) *textHandler {
+	return &textHandler{mu: &sync.Mutex{}, w: w, level: level}
+}
+
+func (h *textHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *textHandler) Handle(_ context.Context, r slog.Record) error {
+	var buf bytes.Buffer
+
+	buf.WriteString(levelLabel(r.Level))
+	subsystem := h.subsystem
+
+	var attrs bytes.Buffer
+	attrs.Write(h.attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		if h.prefix == "" && a.Key == SubsystemKey {
+			subsystem = a.Value.String()
+			return true
+		}
+		appendAttr(&attrs, h.prefix, a)
+		return true
+	})
+
+	if subsystem != "" {
+		buf.WriteString(" [" + subsystem + "]")
+	}
+	buf.WriteString(" " + r.Message)
+	buf.Write(attrs.Bytes())
+	buf.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.w.Write(buf.Bytes())
+	return err
+}
+
+func (h *textHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	buf := bytes.NewBuffer(append([]byte(nil), h.attrs...))
+	for _, a := range attrs {
+		if h.prefix == "" && a.Key == SubsystemKey {
+			c.subsystem = a.Value.String()
+			continue
+		}
+		appendAttr(buf, h.prefix, a)
+	}
+	c.attrs = buf.Bytes()
+	return &c
+}
+
+func (h *textHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	c := *h
+	c.prefix = h.prefix + name + "."
+	return &c
+}
+
+// levelLabel pads the level so the messages line up.
+func levelLabel(level slog.Level) string {
+	label := level.String()
+	return label + strings.Repeat(" ", max(0, 5-len(label)))
+}
+
+func appendAttr(buf *bytes.Buffer, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			appendAttr(buf, prefix, ga)
+		}
+		return
+	}
+
+	buf.WriteString(" " + prefix + a.Key + "=" + quoteValue(a.Value.String()))
+}
+
+func quoteValue(s string) string {
+	if s == "" || strings.ContainsAny(s, " =\"\t\n") {
+		return strconv.Quote(s)
+	}
+	return s
+}
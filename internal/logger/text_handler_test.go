@@ -0,0 +1,29 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestTextHandler(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(newTextHandler(&buf, slog.LevelInfo))
+
+	log.With(SubsystemKey, "ws").Warn("connection lost", "attempt", 2, "error", errors.New("read: connection reset"))
+	log.Info("listening", "url", "http://localhost:3000", "delay", 1500*time.Millisecond, "empty", "")
+	log.WithGroup("request").Error("failed", "status", 500, slog.Group("headers", "accept", "*/*"))
+	log.Info("tagged", SubsystemKey, "payments")
+	log.Debug("hidden")
+
+	want := `WARN  [ws] connection lost attempt=2 error="read: connection reset"
+INFO  listening url=http://localhost:3000 delay=1.5s empty=""
+ERROR failed request.status=500 request.headers.accept=*/*
+INFO  [payments] tagged
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
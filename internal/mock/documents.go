@@ -0,0 +1,206 @@
+package mock
+
+import (
+	"fmt"
+	"strings"
+
+	"abacatepay-cli/internal/types"
+)
+
+type region struct {
+	State   string
+	City    string
+	DDD     int
+	CEPFrom int
+	CEPTo   int
+}
+
+// regions maps each capital to its area code and the CEP range of the city itself,
+// so generated addresses and phones are consistent with each other.
+var regions = []region{
+	{State: "SP", City: "São Paulo", DDD: 11, CEPFrom: 1000000, CEPTo: 5999999},
+	{State: "RJ", City: "Rio de Janeiro", DDD: 21, CEPFrom: 20000000, CEPTo: 23799999},
+	{State: "MG", City: "Belo Horizonte", DDD: 31, CEPFrom: 30000000, CEPTo: 31999999},
+	{State: "ES", City: "Vitória", DDD: 27, CEPFrom: 29000000, CEPTo: 29099999},
+	{State: "PR", City: "Curitiba", DDD: 41, CEPFrom: 80000000, CEPTo: 82999999},
+	{State: "SC", City: "Florianópolis", DDD: 48, CEPFrom: 88000000, CEPTo: 88099999},
+	{State: "RS", City: "Porto Alegre", DDD: 51, CEPFrom: 90000000, CEPTo: 91999999},
+	{State: "DF", City: "Brasília", DDD: 61, CEPFrom: 70000000, CEPTo: 72799999},
+	{State: "GO", City: "Goiânia", DDD: 62, CEPFrom: 74000000, CEPTo: 74899999},
+	{State: "BA", City: "Salvador", DDD: 71, CEPFrom: 40000000, CEPTo: 42599999},
+	{State: "PE", City: "Recife", DDD: 81, CEPFrom: 50000000, CEPTo: 52999999},
+	{State: "CE", City: "Fortaleza", DDD: 85, CEPFrom: 60000000, CEPTo: 61599999},
+	{State: "PA", City: "Belém", DDD: 91, CEPFrom: 66000000, CEPTo: 66999999},
+	{State: "AM", City: "Manaus", DDD: 92, CEPFrom: 69000000, CEPTo: 69099999},
+}
+
+var validDDDs = []int{
+	11, 12, 13, 14, 15, 16, 17, 18, 19,
+	21, 22, 24, 27, 28,
+	31, 32, 33, 34, 35, 37, 38,
+	41, 42, 43, 44, 45, 46, 47, 48, 49,
+	51, 53, 54, 55,
+	61, 62, 63, 64, 65, 66, 67, 68, 69,
+	71, 73, 74, 75, 77, 79,
+	81, 82, 83, 84, 85, 86, 87, 88, 89,
+	91, 92, 93, 94, 95, 96, 97, 98, 99,
+}
+
+var (
+	streetPrefixes = []string{"Rua", "Avenida", "Travessa", "Alameda"}
+	streetNames    = []string{
+		"das Flores", "Sete de Setembro", "XV de Novembro", "Santos Dumont", "Getúlio Vargas",
+		"Tiradentes", "Dom Pedro II", "Marechal Deodoro", "Brasil", "São João", "Rui Barbosa",
+		"Castro Alves", "dos Andradas", "Floriano Peixoto", "Barão do Rio Branco",
+	}
+	neighborhoods = []string{
+		"Centro", "Jardim América", "Vila Nova", "Boa Vista", "Bela Vista", "Santa Cecília",
+		"Liberdade", "Consolação", "Jardim Botânico", "Aldeota", "Savassi", "Moinhos de Vento",
+	}
+)
+
+type CustomerOptions struct {
+	// TaxIDType is "cpf", "cnpj" or empty to pick one at random.
+	TaxIDType string
+	Formatted bool
+}
+
+func CPF(formatted bool) string {
+	cpf := generateValidCPF()
+	if formatted {
+		return FormatCPF(cpf)
+	}
+	return cpf
+}
+
+func CNPJ(formatted bool) string {
+	cnpj := generateValidCNPJ()
+	if formatted {
+		return FormatCNPJ(cnpj)
+	}
+	return cnpj
+}
+
+func Cellphone(formatted bool) string {
+	return cellphoneWithDDD(validDDDs[faker.IntN(len(validDDDs))], formatted)
+}
+
+func FormatCPF(cpf string) string {
+	if len(cpf) != 11 {
+		return cpf
+	}
+	return fmt.Sprintf("%s.%s.%s-%s", cpf[:3], cpf[3:6], cpf[6:9], cpf[9:])
+}
+
+func FormatCNPJ(cnpj string) string {
+	if len(cnpj) != 14 {
+		return cnpj
+	}
+	return fmt.Sprintf("%s.%s.%s/%s-%s", cnpj[:2], cnpj[2:5], cnpj[5:8], cnpj[8:12], cnpj[12:])
+}
+
+func Address(formatted bool) *types.Address {
+	return addressIn(regions[faker.IntN(len(regions))], formatted)
+}
+
+func Customer(opts CustomerOptions) (*types.CustomerRecord, error) {
+	kind := opts.TaxIDType
+	if kind == "" {
+		kind = []string{"cpf", "cnpj"}[faker.IntN(2)]
+	}
+
+	reg := regions[faker.IntN(len(regions))]
+	customer := &types.CustomerRecord{
+		Cellphone: cellphoneWithDDD(reg.DDD, opts.Formatted),
+		TaxIDType: strings.ToUpper(kind),
+		Address:   addressIn(reg, opts.Formatted),
+	}
+
+	switch kind {
+	case "cpf":
+		customer.Name = faker.Name()
+		customer.Email = faker.Email()
+		customer.TaxID = CPF(opts.Formatted)
+	case "cnpj":
+		customer.Name = faker.Company()
+		customer.Email = fmt.Sprintf("financeiro@%s.com.br", slug(customer.Name))
+		customer.TaxID = CNPJ(opts.Formatted)
+	default:
+		return nil, fmt.Errorf("invalid tax ID type: %s (valid: cpf, cnpj)", opts.TaxIDType)
+	}
+
+	return customer, nil
+}
+
+func generateValidCNPJ() string {
+	digits := make([]int, 14)
+	for i := range 8 {
+		digits[i] = faker.IntN(10)
+	}
+	// Branch 0001 is the headquarters, which is what almost every merchant uses.
+	digits[11] = 1
+
+	firstWeights := []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	secondWeights := []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+
+	digits[12] = cnpjDigit(digits[:12], firstWeights)
+	digits[13] = cnpjDigit(digits[:13], secondWeights)
+
+	var b strings.Builder
+	for _, d := range digits {
+		b.WriteByte('0' + byte(d))
+	}
+
+	return b.String()
+}
+
+func cnpjDigit(digits, weights []int) int {
+	sum := 0
+	for i, w := range weights {
+		sum += digits[i] * w
+	}
+
+	remainder := sum % 11
+	if remainder < 2 {
+		return 0
+	}
+	return 11 - remainder
+}
+
+func cellphoneWithDDD(ddd int, formatted bool) string {
+	// Brazilian mobile numbers always have nine digits starting with 9.
+	number := "9" + faker.Numerify("########")
+	if formatted {
+		return fmt.Sprintf("(%d) %s-%s", ddd, number[:5], number[5:])
+	}
+	return fmt.Sprintf("%d%s", ddd, number)
+}
+
+func addressIn(reg region, formatted bool) *types.Address {
+	cep := fmt.Sprintf("%08d", reg.CEPFrom+faker.IntN(reg.CEPTo-reg.CEPFrom+1))
+	if formatted {
+		cep = cep[:5] + "-" + cep[5:]
+	}
+
+	return &types.Address{
+		Street:       streetPrefixes[faker.IntN(len(streetPrefixes))] + " " + streetNames[faker.IntN(len(streetNames))],
+		Number:       fmt.Sprintf("%d", faker.Number(1, 3000)),
+		Neighborhood: neighborhoods[faker.IntN(len(neighborhoods))],
+		City:         reg.City,
+		State:        reg.State,
+		ZipCode:      cep,
+	}
+}
+
+func slug(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 {
+		return "empresa"
+	}
+	return b.String()
+}
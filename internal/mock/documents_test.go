@@ -0,0 +1,151 @@
+package mock
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+const iterations = 1000
+
+// validCPF and validCNPJ check the verification digits independently of the
+// generators, using the algorithm published by the Receita Federal.
+func validCPF(cpf string) bool {
+	if !regexp.MustCompile(`^\d{11}$`).MatchString(cpf) {
+		return false
+	}
+	for n := 9; n <= 10; n++ {
+		sum := 0
+		for i := range n {
+			sum += int(cpf[i]-'0') * (n + 1 - i)
+		}
+		want := sum * 10 % 11 % 10
+		if int(cpf[n]-'0') != want {
+			return false
+		}
+	}
+	return true
+}
+
+func validCNPJ(cnpj string) bool {
+	if !regexp.MustCompile(`^\d{14}$`).MatchString(cnpj) {
+		return false
+	}
+	for n := 12; n <= 13; n++ {
+		sum, weight := 0, n-7
+		for i := range n {
+			sum += int(cnpj[i]-'0') * weight
+			if weight--; weight < 2 {
+				weight = 9
+			}
+		}
+		want := 0
+		if r := sum % 11; r >= 2 {
+			want = 11 - r
+		}
+		if int(cnpj[n]-'0') != want {
+			return false
+		}
+	}
+	return true
+}
+
+func TestValidators_KnownDocuments(t *testing.T) {
+	if !validCPF("52998224725") || validCPF("52998224724") {
+		t.Fatal("CPF validator doesn't match the known document")
+	}
+	if !validCNPJ("11222333000181") || validCNPJ("11222333000182") {
+		t.Fatal("CNPJ validator doesn't match the known document")
+	}
+}
+
+func TestCNPJDigit(t *testing.T) {
+	digits := []int{1, 1, 2, 2, 2, 3, 3, 3, 0, 0, 0, 1, 8}
+	first := []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	second := []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+
+	if got := cnpjDigit(digits, first); got != 8 {
+		t.Errorf("expected first digit 8, got %d", got)
+	}
+	if got := cnpjDigit(digits, second); got != 1 {
+		t.Errorf("expected second digit 1, got %d", got)
+	}
+	// A remainder below 2 gives 0.
+	if got := cnpjDigit([]int{1}, []int{11}); got != 0 {
+		t.Errorf("expected 0 for remainder 0, got %d", got)
+	}
+}
+
+func TestDocuments_ValidChecksums(t *testing.T) {
+	cpfFormat := regexp.MustCompile(`^\d{3}\.\d{3}\.\d{3}-\d{2}$`)
+	cnpjFormat := regexp.MustCompile(`^\d{2}\.\d{3}\.\d{3}/0001-\d{2}$`)
+
+	for seed := range int64(iterations) {
+		Seed(seed)
+
+		if cnpj := generateValidCNPJ(); !validCNPJ(cnpj) || cnpj[8:12] != "0001" {
+			t.Fatalf("seed %d: invalid CNPJ %s", seed, cnpj)
+		}
+		if cpf := CPF(false); !validCPF(cpf) {
+			t.Fatalf("seed %d: invalid CPF %s", seed, cpf)
+		}
+		if cnpj := CNPJ(false); !validCNPJ(cnpj) {
+			t.Fatalf("seed %d: invalid CNPJ %s", seed, cnpj)
+		}
+
+		cpf := CPF(true)
+		if !cpfFormat.MatchString(cpf) || !validCPF(regexp.MustCompile(`\D`).ReplaceAllString(cpf, "")) {
+			t.Fatalf("seed %d: invalid formatted CPF %s", seed, cpf)
+		}
+		cnpj := CNPJ(true)
+		if !cnpjFormat.MatchString(cnpj) || !validCNPJ(regexp.MustCompile(`\D`).ReplaceAllString(cnpj, "")) {
+			t.Fatalf("seed %d: invalid formatted CNPJ %s", seed, cnpj)
+		}
+	}
+}
+
+func TestCellphone_ValidDDDAndMobilePrefix(t *testing.T) {
+	unformatted := regexp.MustCompile(`^(\d{2})(9\d{8})$`)
+	formatted := regexp.MustCompile(`^\((\d{2})\) (9\d{4})-(\d{4})$`)
+
+	validDDD := func(s string) bool {
+		ddd, err := strconv.Atoi(s)
+		return err == nil && slices.Contains(validDDDs, ddd)
+	}
+
+	for _, reg := range regions {
+		if !slices.Contains(validDDDs, reg.DDD) {
+			t.Errorf("region %s has an invalid DDD %d", reg.State, reg.DDD)
+		}
+	}
+
+	for seed := range int64(iterations) {
+		Seed(seed)
+
+		m := unformatted.FindStringSubmatch(Cellphone(false))
+		if m == nil || !validDDD(m[1]) {
+			t.Fatalf("seed %d: invalid cellphone %v", seed, m)
+		}
+
+		phone := Cellphone(true)
+		if m := formatted.FindStringSubmatch(phone); m == nil || !validDDD(m[1]) {
+			t.Fatalf("seed %d: invalid formatted cellphone %s", seed, phone)
+		}
+
+		for _, ddd := range []int{11, 99} {
+			phone := cellphoneWithDDD(ddd, false)
+			if !unformatted.MatchString(phone) || phone[:2] != strconv.Itoa(ddd) {
+				t.Fatalf("seed %d: expected a mobile number with DDD %d, got %s", seed, ddd, phone)
+			}
+		}
+
+		customer, err := Customer(CustomerOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m := unformatted.FindStringSubmatch(customer.Cellphone); m == nil || !validDDD(m[1]) {
+			t.Fatalf("seed %d: invalid customer cellphone %s", seed, customer.Cellphone)
+		}
+	}
+}
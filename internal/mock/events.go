@@ -2,16 +2,26 @@ package mock
 
 import (
 	"fmt"
-	"time"
 
 	"abacatepay-cli/internal/types"
-
-	"github.com/brianvoe/gofakeit/v7"
 )
 
+func MockBillingCreatedEvent() *types.BillingCreatedEvent {
+	evt := &types.BillingCreatedEvent{
+		ID:      mockEventID(),
+		DevMode: true,
+		Event:   "billing.created",
+	}
+
+	evt.Data.Billing = mockBilling(faker.Number(100, 1000), "PENDING", "billing-created")
+	evt.Data.Customer = mockEventCustomer()
+
+	return evt
+}
+
 func MockBillingPaidEvent() *types.BillingPaidEvent {
-	amount := gofakeit.Number(100, 1000)
-	id := fmt.Sprintf("evt_%s", gofakeit.LetterN(10))
+	amount := faker.Number(100, 1000)
+	id := fmt.Sprintf("evt_%s", faker.LetterN(10))
 
 	return &types.BillingPaidEvent{
 		ID: id,
@@ -21,12 +31,12 @@ func MockBillingPaidEvent() *types.BillingPaidEvent {
 		}{
 			Payment: &types.EventPayment{
 				Amount: amount,
-				Fee:    gofakeit.Number(10, 100),
+				Fee:    faker.Number(10, 100),
 				Method: "PIX",
 			},
 			Billing: &types.EventBilling{
-				ID:         fmt.Sprintf("bill_%s", gofakeit.LetterN(10)),
-				ExternalID: gofakeit.UUID(),
+				ID:         fmt.Sprintf("bill_%s", faker.LetterN(10)),
+				ExternalID: faker.UUID(),
 				Amount:     amount,
 				URL:        "https://docs.abacatepay.com/pages/webhooks#billing-paid",
 				Status:     "PAID",
@@ -37,6 +47,47 @@ func MockBillingPaidEvent() *types.BillingPaidEvent {
 	}
 }
 
+func MockBillingRefundedEvent() *types.BillingRefundedEvent {
+	amount := faker.Number(100, 1000)
+
+	evt := &types.BillingRefundedEvent{
+		ID:      mockEventID(),
+		DevMode: true,
+		Event:   "billing.refunded",
+	}
+
+	evt.Data.Billing = mockBilling(amount, "REFUNDED", "billing-refunded")
+	evt.Data.Refund = &types.EventRefund{
+		ID:        fmt.Sprintf("ref_%s", faker.LetterN(10)),
+		Amount:    amount,
+		Reason:    "REQUESTED_BY_CUSTOMER",
+		CreatedAt: now(),
+	}
+
+	return evt
+}
+
+func MockBillingDisputedEvent() *types.BillingDisputedEvent {
+	amount := faker.Number(100, 1000)
+
+	evt := &types.BillingDisputedEvent{
+		ID:      mockEventID(),
+		DevMode: true,
+		Event:   "billing.disputed",
+	}
+
+	evt.Data.Billing = mockBilling(amount, "DISPUTED", "billing-disputed")
+	evt.Data.Dispute = &types.EventDispute{
+		ID:        fmt.Sprintf("disp_%s", faker.LetterN(10)),
+		Amount:    amount,
+		Reason:    "FRAUD",
+		Status:    "OPEN",
+		CreatedAt: now(),
+	}
+
+	return evt
+}
+
 func MockPayoutEvent(isDone bool) *types.PayoutEvent {
 	status := "CANCELLED"
 	event := "payout.failed"
@@ -45,8 +96,8 @@ func MockPayoutEvent(isDone bool) *types.PayoutEvent {
 		event = "payout.done"
 	}
 
-	amount := gofakeit.Number(1000, 50000)
-	id := fmt.Sprintf("evt_%s", gofakeit.LetterN(10))
+	amount := faker.Number(1000, 50000)
+	id := fmt.Sprintf("evt_%s", faker.LetterN(10))
 
 	return &types.PayoutEvent{
 		ID: id,
@@ -54,19 +105,106 @@ func MockPayoutEvent(isDone bool) *types.PayoutEvent {
 			Transaction *types.EventTransaction `json:"transaction,omitempty"`
 		}{
 			Transaction: &types.EventTransaction{
-				ID:          fmt.Sprintf("tran_%s", gofakeit.LetterN(16)),
+				ID:          fmt.Sprintf("tran_%s", faker.LetterN(16)),
 				Status:      status,
 				DevMode:     true,
 				ReceiptURL:  "https://abacatepay.com/receipt/mock",
 				Kind:        "WITHDRAW",
 				Amount:      amount,
 				PlatformFee: 0,
-				ExternalID:  gofakeit.UUID(),
-				CreatedAt:   time.Now(),
-				UpdatedAt:   time.Now(),
+				ExternalID:  faker.UUID(),
+				CreatedAt:   now(),
+				UpdatedAt:   now(),
 			},
 		},
 		DevMode: true,
 		Event:   event,
 	}
 }
+
+func MockWithdrawEvent(isDone bool) *types.WithdrawEvent {
+	status := "CANCELLED"
+	event := "withdraw.failed"
+	if isDone {
+		status = "COMPLETE"
+		event = "withdraw.done"
+	}
+
+	evt := &types.WithdrawEvent{
+		ID:      mockEventID(),
+		DevMode: true,
+		Event:   event,
+	}
+
+	evt.Data.Transaction = &types.EventTransaction{
+		ID:          fmt.Sprintf("tran_%s", faker.LetterN(16)),
+		Status:      status,
+		DevMode:     true,
+		ReceiptURL:  "https://abacatepay.com/receipt/mock",
+		Kind:        "WITHDRAW",
+		Amount:      faker.Number(1000, 50000),
+		PlatformFee: 80,
+		ExternalID:  faker.UUID(),
+		CreatedAt:   now(),
+		UpdatedAt:   now(),
+	}
+
+	return evt
+}
+
+func MockSubscriptionEvent(event string) *types.SubscriptionEvent {
+	now := now()
+	subscription := &types.EventSubscription{
+		ID:         fmt.Sprintf("subs_%s", faker.LetterN(10)),
+		Amount:     faker.Number(990, 9990),
+		Frequency:  "MONTHLY",
+		Method:     "PIX",
+		ExternalID: faker.UUID(),
+		CreatedAt:  now,
+	}
+
+	switch event {
+	case "subscription.canceled":
+		subscription.Status = "CANCELLED"
+		subscription.CanceledAt = &now
+	default:
+		next := now.AddDate(0, 1, 0)
+		subscription.Status = "ACTIVE"
+		subscription.NextBillingDate = &next
+	}
+
+	evt := &types.SubscriptionEvent{
+		ID:      mockEventID(),
+		DevMode: true,
+		Event:   event,
+	}
+
+	evt.Data.Subscription = subscription
+	evt.Data.Customer = mockEventCustomer()
+
+	return evt
+}
+
+func mockEventID() string {
+	return fmt.Sprintf("evt_%s", faker.LetterN(10))
+}
+
+func mockBilling(amount int, status, anchor string) *types.EventBilling {
+	return &types.EventBilling{
+		ID:         fmt.Sprintf("bill_%s", faker.LetterN(10)),
+		ExternalID: faker.UUID(),
+		Amount:     amount,
+		URL:        "https://docs.abacatepay.com/pages/webhooks#" + anchor,
+		Status:     status,
+	}
+}
+
+func mockEventCustomer() *types.EventCustomer {
+	return &types.EventCustomer{
+		ID:        fmt.Sprintf("cust_%s", faker.LetterN(10)),
+		Name:      faker.Name(),
+		Email:     faker.Email(),
+		TaxID:     generateValidCPF(),
+		Cellphone: Cellphone(false),
+	}
+}
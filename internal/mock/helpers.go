@@ -1,15 +1,12 @@
 // Package mock...
 package mock
 
-import (
-	"math/rand"
-	"strings"
-)
+import "strings"
 
-func generateValidCPF(r *rand.Rand) string {
+func generateValidCPF() string {
 	digits := make([]int, 11)
 	for i := range 9 {
-		digits[i] = r.Intn(10)
+		digits[i] = faker.IntN(10)
 	}
 
 	sum := 0
@@ -0,0 +1,101 @@
+package mock
+
+import (
+	"fmt"
+	"strings"
+
+	"abacatepay-cli/internal/types"
+)
+
+type EventDefinition struct {
+	Name        string
+	Description string
+	Generate    func() types.Event
+}
+
+var catalog = []EventDefinition{
+	{
+		Name:        "billing.created",
+		Description: "A new billing (charge) was created and is waiting for payment",
+		Generate:    func() types.Event { return MockBillingCreatedEvent() },
+	},
+	{
+		Name:        "billing.paid",
+		Description: "A billing was paid by the customer",
+		Generate:    func() types.Event { return MockBillingPaidEvent() },
+	},
+	{
+		Name:        "billing.refunded",
+		Description: "A paid billing was refunded to the customer",
+		Generate:    func() types.Event { return MockBillingRefundedEvent() },
+	},
+	{
+		Name:        "billing.disputed",
+		Description: "The customer opened a dispute against a paid billing",
+		Generate:    func() types.Event { return MockBillingDisputedEvent() },
+	},
+	{
+		Name:        "payout.done",
+		Description: "A payout to your bank account was completed",
+		Generate:    func() types.Event { return MockPayoutEvent(true) },
+	},
+	{
+		Name:        "payout.failed",
+		Description: "A payout to your bank account failed or was cancelled",
+		Generate:    func() types.Event { return MockPayoutEvent(false) },
+	},
+	{
+		Name:        "withdraw.done",
+		Description: "A withdraw from your AbacatePay balance was completed",
+		Generate:    func() types.Event { return MockWithdrawEvent(true) },
+	},
+	{
+		Name:        "withdraw.failed",
+		Description: "A withdraw from your AbacatePay balance failed or was cancelled",
+		Generate:    func() types.Event { return MockWithdrawEvent(false) },
+	},
+	{
+		Name:        "subscription.created",
+		Description: "A customer subscribed to a recurring plan",
+		Generate:    func() types.Event { return MockSubscriptionEvent("subscription.created") },
+	},
+	{
+		Name:        "subscription.renewed",
+		Description: "A subscription was charged for a new billing cycle",
+		Generate:    func() types.Event { return MockSubscriptionEvent("subscription.renewed") },
+	},
+	{
+		Name:        "subscription.canceled",
+		Description: "A subscription was canceled and will not be charged again",
+		Generate:    func() types.Event { return MockSubscriptionEvent("subscription.canceled") },
+	},
+}
+
+func Events() []EventDefinition {
+	return catalog
+}
+
+func EventNames() []string {
+	names := make([]string, 0, len(catalog))
+	for _, def := range catalog {
+		names = append(names, def.Name)
+	}
+	return names
+}
+
+func LookupEvent(name string) (EventDefinition, bool) {
+	for _, def := range catalog {
+		if def.Name == name {
+			return def, true
+		}
+	}
+	return EventDefinition{}, false
+}
+
+func GenerateEvent(name string) (types.Event, error) {
+	def, ok := LookupEvent(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown event type: %s. Available: %s", name, strings.Join(EventNames(), ", "))
+	}
+	return def.Generate(), nil
+}
@@ -0,0 +1,23 @@
+package mock
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+)
+
+var (
+	faker = gofakeit.New(0)
+	now   = time.Now
+)
+
+// Seed makes every generator in this package deterministic: the same seed always
+// yields byte-identical customers, documents, amounts, IDs and timestamps.
+func Seed(seed int64) {
+	faker = gofakeit.NewFaker(rand.NewPCG(uint64(seed), uint64(seed)), true)
+
+	fixed := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC).
+		Add(time.Duration(faker.IntN(365*24*60*60)) * time.Second)
+	now = func() time.Time { return fixed }
+}
@@ -0,0 +1,34 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestSeed_Deterministic(t *testing.T) {
+	generate := func() []byte {
+		Seed(42)
+
+		var buf bytes.Buffer
+		for _, def := range Events() {
+			b, err := json.Marshal(def.Generate())
+			if err != nil {
+				t.Fatalf("failed to marshal %s: %v", def.Name, err)
+			}
+			buf.Write(b)
+		}
+
+		b, _ := json.Marshal(CreatePixQRCodeMock())
+		buf.Write(b)
+
+		return buf.Bytes()
+	}
+
+	first := generate()
+	second := generate()
+
+	if !bytes.Equal(first, second) {
+		t.Error("The same seed should produce byte-identical payloads")
+	}
+}
@@ -0,0 +1,153 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"abacatepay-cli/internal/style"
+)
+
+// EventLog is everything the transaction log knows about one event.
+type EventLog struct {
+	ID         string          `json:"id"`
+	Event      string          `json:"event"`
+	Status     string          `json:"status"`
+	Payload    json.RawMessage `json:"payload,omitempty"`
+	Deliveries []DeliveryLog   `json:"deliveries"`
+}
+
+type DeliveryLog struct {
+	ID         string           `json:"deliveryId,omitempty"`
+	ReceivedAt time.Time        `json:"receivedAt,omitzero"`
+	Attempts   []ForwardAttempt `json:"attempts"`
+}
+
+type ForwardAttempt struct {
+	Time       time.Time `json:"time"`
+	URL        string    `json:"url"`
+	StatusCode int       `json:"statusCode,omitempty"`
+	DurationMs int64     `json:"durationMs"`
+	Signature  string    `json:"signature,omitempty"`
+	Response   string    `json:"response,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+func (a ForwardAttempt) status() string {
+	if a.Error != "" {
+		return "error"
+	}
+	return fmt.Sprintf("%d %s", a.StatusCode, http.StatusText(a.StatusCode))
+}
+
+func PrintEventLog(e EventLog) {
+	switch GetFormat() {
+	case FormatJSON:
+		style.PrintJSON(e)
+	case FormatTable:
+		printEventLogTable(e)
+	default:
+		printEventLogText(e)
+	}
+}
+
+func printEventLogTable(e EventLog) {
+	var rows [][]string
+	for _, d := range e.Deliveries {
+		for _, a := range d.Attempts {
+			rows = append(rows, []string{
+				d.ID,
+				a.Time.Local().Format(time.DateTime + ".000"),
+				a.URL,
+				a.status(),
+				fmt.Sprintf("%dms", a.DurationMs),
+				shorten(a.Response+a.Error, 40),
+			})
+		}
+	}
+
+	style.PrintTable([]string{"Delivery", "Time", "Target", "Status", "Latency", "Response"}, rows)
+}
+
+func printEventLogText(e EventLog) {
+	style.PrintSuccess("Event "+e.ID, map[string]string{
+		"Event":      e.Event,
+		"Status":     e.Status,
+		"Deliveries": fmt.Sprintf("%d", len(e.Deliveries)),
+	})
+
+	if len(e.Payload) > 0 {
+		fmt.Println(style.TitleStyle.Render("Payload"))
+		fmt.Println(indentJSON(e.Payload))
+		fmt.Println()
+	}
+
+	for _, d := range e.Deliveries {
+		title := "Delivery"
+		if d.ID != "" {
+			title += " " + d.ID
+		}
+
+		when := "resend"
+		if !d.ReceivedAt.IsZero() {
+			when = "received " + d.ReceivedAt.Local().Format(time.DateTime+".000")
+		}
+		fmt.Printf("%s %s\n", style.TitleStyle.Render(title), style.LabelStyle.Render("("+when+")"))
+
+		if len(d.Attempts) == 0 {
+			fmt.Printf("  %s\n\n", style.LabelStyle.Render("not forwarded"))
+			continue
+		}
+
+		for _, a := range d.Attempts {
+			status := style.ValueStyle.Render(a.status())
+			if a.Error != "" || a.StatusCode < 200 || a.StatusCode >= 300 {
+				status = style.ErrorStyle.Render(a.status())
+			}
+
+			fmt.Printf("  %s  POST %s  %s  %s\n",
+				style.LabelStyle.Render(a.Time.Local().Format("15:04:05.000")),
+				a.URL,
+				status,
+				style.LabelStyle.Render(fmt.Sprintf("%dms", a.DurationMs)),
+			)
+			if a.Signature != "" {
+				fmt.Printf("    %s %s\n", style.LabelStyle.Render("Signature:"), a.Signature)
+			}
+			if a.Error != "" {
+				fmt.Printf("    %s %s\n", style.LabelStyle.Render("Error:"), a.Error)
+			}
+			if a.Response != "" {
+				fmt.Printf("    %s\n%s\n", style.LabelStyle.Render("Response:"), indent(indentJSON([]byte(a.Response)), "      "))
+			}
+		}
+		fmt.Println()
+	}
+}
+
+// indentJSON pretty-prints data when it's JSON and returns it as is otherwise.
+func indentJSON(data []byte) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return string(data)
+	}
+	return buf.String()
+}
+
+func indent(s, prefix string) string {
+	return prefix + strings.ReplaceAll(s, "\n", "\n"+prefix)
+}
+
+// shorten collapses whitespace and truncates s to n characters, counting runes
+// so multi-byte characters aren't cut in half.
+func shorten(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-3]) + "..."
+}
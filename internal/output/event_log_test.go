@@ -0,0 +1,126 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	fn()
+	w.Close()
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func testEventLog() EventLog {
+	at := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	return EventLog{
+		ID:      "evt_1",
+		Event:   "billing.paid",
+		Status:  "delivered [200]",
+		Payload: json.RawMessage(`{"id":"evt_1"}`),
+		Deliveries: []DeliveryLog{{
+			ID:         "dlv_1",
+			ReceivedAt: at,
+			Attempts: []ForwardAttempt{
+				{Time: at, URL: "http://localhost:3000/webhook", StatusCode: 500, DurationMs: 12, Signature: "sha256=abc", Response: `{"error":"boom"}`},
+				{Time: at.Add(time.Second), URL: "http://localhost:3000/webhook", StatusCode: 200, DurationMs: 8},
+			},
+		}},
+	}
+}
+
+func TestPrintEventLog_JSON(t *testing.T) {
+	SetFormat(FormatJSON)
+	defer SetFormat(FormatText)
+
+	out := captureStdout(t, func() { PrintEventLog(testEventLog()) })
+
+	var got EventLog
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("expected JSON output, got %q: %v", out, err)
+	}
+	if got.ID != "evt_1" || len(got.Deliveries) != 1 || len(got.Deliveries[0].Attempts) != 2 {
+		t.Fatalf("unexpected event log %+v", got)
+	}
+	if a := got.Deliveries[0].Attempts[0]; a.Signature != "sha256=abc" || a.Response != `{"error":"boom"}` {
+		t.Fatalf("expected the signature and response of the attempt, got %+v", a)
+	}
+}
+
+func TestPrintEventLog_Text(t *testing.T) {
+	SetFormat(FormatText)
+
+	out := captureStdout(t, func() { PrintEventLog(testEventLog()) })
+
+	for _, want := range []string{"Delivery dlv_1", "500 Internal Server Error", "200 OK", "sha256=abc", `"error": "boom"`, `"id": "evt_1"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestPrintEventLog_NotForwarded(t *testing.T) {
+	SetFormat(FormatText)
+
+	e := testEventLog()
+	e.Deliveries = []DeliveryLog{{ID: "dlv_2", Attempts: []ForwardAttempt{}}}
+
+	out := captureStdout(t, func() { PrintEventLog(e) })
+	if !strings.Contains(out, "(resend)") || !strings.Contains(out, "not forwarded") {
+		t.Errorf("expected a resend that wasn't forwarded, got:\n%s", out)
+	}
+}
+
+func TestIndentJSON(t *testing.T) {
+	if got := indentJSON([]byte(`{"a":1}`)); got != "{\n  \"a\": 1\n}" {
+		t.Errorf("expected indented JSON, got %q", got)
+	}
+	if got := indentJSON([]byte("not json")); got != "not json" {
+		t.Errorf("expected non-JSON as is, got %q", got)
+	}
+}
+
+func TestShorten(t *testing.T) {
+	tests := []struct {
+		in   string
+		n    int
+		want string
+	}{
+		{"short", 10, "short"},
+		{"  spaced \n out\t", 20, "spaced out"},
+		{"0123456789", 10, "0123456789"},
+		{"0123456789abc", 10, "0123456..."},
+		{"pagamento não autorizado", 12, "pagamento..."},
+		{"ção ção ção", 7, "ção ..."},
+	}
+
+	for _, tt := range tests {
+		got := shorten(tt.in, tt.n)
+		if got != tt.want {
+			t.Errorf("shorten(%q, %d) = %q, want %q", tt.in, tt.n, got, tt.want)
+		}
+		if !strings.HasSuffix(got, "...") && got != strings.Join(strings.Fields(tt.in), " ") {
+			t.Errorf("shorten(%q, %d) changed a string that fits", tt.in, tt.n)
+		}
+	}
+}
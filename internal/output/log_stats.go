@@ -0,0 +1,171 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"abacatepay-cli/internal/style"
+)
+
+// LogStats summarizes the transaction log over a period of time.
+type LogStats struct {
+	Since       time.Time      `json:"since,omitzero"`
+	Until       time.Time      `json:"until,omitzero"`
+	Deliveries  int            `json:"deliveries"`
+	Attempts    int            `json:"attempts"`
+	Succeeded   int            `json:"succeeded"`
+	Failed      int            `json:"failed"`
+	SuccessRate float64        `json:"successRate"`
+	LatencyMs   LatencyMs      `json:"latencyMs"`
+	Events      []EventCount   `json:"events"`
+	Targets     []TargetStats  `json:"targets"`
+	Slowest     []SlowForward  `json:"slowest"`
+	MostFailing []FailingEvent `json:"mostFailing"`
+}
+
+type LatencyMs struct {
+	P50 float64 `json:"p50"`
+	P95 float64 `json:"p95"`
+	P99 float64 `json:"p99"`
+	Max float64 `json:"max"`
+}
+
+type EventCount struct {
+	Event      string `json:"event"`
+	Deliveries int    `json:"deliveries"`
+	Delivered  int    `json:"delivered"`
+	Failed     int    `json:"failed"`
+	Pending    int    `json:"pending"`
+}
+
+type TargetStats struct {
+	URL         string    `json:"url"`
+	Attempts    int       `json:"attempts"`
+	Succeeded   int       `json:"succeeded"`
+	Failed      int       `json:"failed"`
+	SuccessRate float64   `json:"successRate"`
+	LatencyMs   LatencyMs `json:"latencyMs"`
+}
+
+type SlowForward struct {
+	ID         string    `json:"id"`
+	Event      string    `json:"event"`
+	URL        string    `json:"url"`
+	Time       time.Time `json:"time"`
+	StatusCode int       `json:"statusCode,omitempty"`
+	DurationMs float64   `json:"durationMs"`
+}
+
+type FailingEvent struct {
+	ID        string `json:"id"`
+	Event     string `json:"event"`
+	Attempts  int    `json:"attempts"`
+	Failures  int    `json:"failures"`
+	LastError string `json:"lastError,omitempty"`
+}
+
+func PrintLogStats(s LogStats) {
+	switch GetFormat() {
+	case FormatJSON:
+		style.PrintJSON(s)
+	case FormatTable:
+		printLogStatsTables(s)
+	default:
+		style.PrintSuccess("Log stats", map[string]string{
+			"Period":      formatPeriod(s.Since, s.Until),
+			"Deliveries":  strconv.Itoa(s.Deliveries),
+			"Forwards":    fmt.Sprintf("%d (%d failed)", s.Attempts, s.Failed),
+			"Success":     formatRate(s.SuccessRate),
+			"Latency p50": formatMs(s.LatencyMs.P50),
+			"Latency p95": formatMs(s.LatencyMs.P95),
+			"Latency p99": formatMs(s.LatencyMs.P99),
+		})
+		printLogStatsTables(s)
+	}
+}
+
+func printLogStatsTables(s LogStats) {
+	var rows [][]string
+	for _, e := range s.Events {
+		rows = append(rows, []string{
+			e.Event,
+			strconv.Itoa(e.Deliveries),
+			strconv.Itoa(e.Delivered),
+			strconv.Itoa(e.Failed),
+			strconv.Itoa(e.Pending),
+		})
+	}
+	printSection("Events", []string{"Event", "Deliveries", "Delivered", "Failed", "Pending"}, rows)
+
+	rows = nil
+	for _, t := range s.Targets {
+		rows = append(rows, []string{
+			t.URL,
+			strconv.Itoa(t.Attempts),
+			formatRate(t.SuccessRate),
+			formatRate(1 - t.SuccessRate),
+			formatMs(t.LatencyMs.P50),
+			formatMs(t.LatencyMs.P95),
+			formatMs(t.LatencyMs.P99),
+		})
+	}
+	printSection("Targets", []string{"Target", "Forwards", "Success", "Failure", "p50", "p95", "p99"}, rows)
+
+	rows = nil
+	for _, f := range s.Slowest {
+		status := "error"
+		if f.StatusCode > 0 {
+			status = strconv.Itoa(f.StatusCode)
+		}
+		rows = append(rows, []string{
+			f.Time.Local().Format(time.DateTime),
+			f.Event,
+			f.ID,
+			status,
+			formatMs(f.DurationMs),
+			f.URL,
+		})
+	}
+	printSection("Slowest forwards", []string{"Time", "Event", "ID", "Status", "Latency", "Target"}, rows)
+
+	rows = nil
+	for _, f := range s.MostFailing {
+		rows = append(rows, []string{
+			f.Event,
+			f.ID,
+			fmt.Sprintf("%d/%d", f.Failures, f.Attempts),
+			shorten(f.LastError, 40),
+		})
+	}
+	printSection("Most failing events", []string{"Event", "ID", "Failures", "Last error"}, rows)
+}
+
+func printSection(title string, headers []string, rows [][]string) {
+	if len(rows) == 0 {
+		return
+	}
+	fmt.Println(style.TitleStyle.Render(title))
+	style.PrintTable(headers, rows)
+	fmt.Println()
+}
+
+func formatPeriod(since, until time.Time) string {
+	from := "start of the log"
+	if !since.IsZero() {
+		from = since.Local().Format(time.DateTime)
+	}
+	to := "now"
+	if !until.IsZero() {
+		to = until.Local().Format(time.DateTime)
+	}
+	return from + " to " + to
+}
+
+func formatRate(r float64) string {
+	return fmt.Sprintf("%.1f%%", r*100)
+}
+
+func formatMs(ms float64) string {
+	return fmt.Sprintf("%.0fms", ms)
+}
@@ -0,0 +1,221 @@
+// Package payload reads and rewrites JSON event payloads through dotted paths
+// such as "data.billing.amount" or "data.items.0.id".
+package payload
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Assignment struct {
+	Path  string
+	Value any
+}
+
+func Decode(data []byte) (map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var doc map[string]any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON payload: %w", err)
+	}
+	if doc == nil {
+		return nil, fmt.Errorf("invalid JSON payload: expected an object")
+	}
+
+	return doc, nil
+}
+
+func ParseValue(raw string) any {
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.UseNumber()
+
+	var value any
+	if err := dec.Decode(&value); err != nil || dec.More() {
+		return raw
+	}
+	return value
+}
+
+func ParseAssignment(s string) (Assignment, error) {
+	path, raw, ok := strings.Cut(s, "=")
+	path = strings.TrimSpace(path)
+	if !ok || path == "" {
+		return Assignment{}, fmt.Errorf("invalid override %q. Expected: path=value", s)
+	}
+
+	return Assignment{Path: path, Value: ParseValue(raw)}, nil
+}
+
+func ParseAssignments(items []string) ([]Assignment, error) {
+	assignments := make([]Assignment, 0, len(items))
+	for _, item := range items {
+		a, err := ParseAssignment(item)
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, a)
+	}
+	return assignments, nil
+}
+
+// LoadAssignments reads a JSON object from a file. Every key is a path, so both
+// {"data.billing.amount": 5000} and {"data": {"billing": {"amount": 5000}}} work.
+func LoadAssignments(path string) ([]Assignment, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read overrides file: %w", err)
+	}
+
+	doc, err := Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse overrides file %s: %w", path, err)
+	}
+
+	assignments := make([]Assignment, 0, len(doc))
+	for _, key := range sortedKeys(doc) {
+		assignments = append(assignments, Assignment{Path: key, Value: doc[key]})
+	}
+	return assignments, nil
+}
+
+// Apply re-encodes v as a JSON object and applies every assignment in order.
+// Objects assigned over existing objects are merged instead of replaced.
+func Apply(v any, assignments []Assignment) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	doc, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range assignments {
+		if err := MergeAt(doc, a.Path, a.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	return doc, nil
+}
+
+func Get(doc any, path string) (any, bool) {
+	current := doc
+
+	for _, key := range splitPath(path) {
+		switch node := current.(type) {
+		case map[string]any:
+			next, ok := node[key]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case []any:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return nil, false
+			}
+			current = node[idx]
+		default:
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+func Set(doc map[string]any, path string, value any) error {
+	keys := splitPath(path)
+	if len(keys) == 0 {
+		return fmt.Errorf("empty override path")
+	}
+
+	var current any = doc
+	for i, key := range keys {
+		last := i == len(keys)-1
+
+		switch node := current.(type) {
+		case map[string]any:
+			if last {
+				node[key] = value
+				return nil
+			}
+
+			next, ok := node[key]
+			if !ok || next == nil {
+				next = map[string]any{}
+				node[key] = next
+			}
+			current = next
+
+		case []any:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return fmt.Errorf("invalid index %q in path %s", key, path)
+			}
+			if last {
+				node[idx] = value
+				return nil
+			}
+			current = node[idx]
+
+		default:
+			return fmt.Errorf("cannot set %s: %s is not an object", path, strings.Join(keys[:i], "."))
+		}
+	}
+
+	return nil
+}
+
+func MergeAt(doc map[string]any, path string, value any) error {
+	src, isObject := value.(map[string]any)
+	if !isObject {
+		return Set(doc, path, value)
+	}
+
+	existing, ok := Get(doc, path)
+	if dst, isDstObject := existing.(map[string]any); ok && isDstObject {
+		Merge(dst, src)
+		return nil
+	}
+
+	return Set(doc, path, value)
+}
+
+func Merge(dst, src map[string]any) {
+	for key, value := range src {
+		srcObj, srcIsObj := value.(map[string]any)
+		dstObj, dstIsObj := dst[key].(map[string]any)
+
+		if srcIsObj && dstIsObj {
+			Merge(dstObj, srcObj)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+func splitPath(path string) []string {
+	path = strings.TrimPrefix(strings.TrimSpace(path), "$.")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, ".")
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
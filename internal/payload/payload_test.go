@@ -0,0 +1,50 @@
+package payload
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestApply_SetAndMerge(t *testing.T) {
+	event := map[string]any{
+		"event": "billing.paid",
+		"data": map[string]any{
+			"billing": map[string]any{"id": "bill_1", "amount": 100},
+		},
+	}
+
+	assignments := []Assignment{
+		{Path: "data.billing", Value: map[string]any{"externalId": "order_42"}},
+	}
+
+	parsed, err := ParseAssignments([]string{"data.billing.amount=5000", "data.note=hello world"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	doc, err := Apply(event, append(assignments, parsed...))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if v, _ := Get(doc, "data.billing.amount"); v != json.Number("5000") {
+		t.Errorf("Expected amount 5000, got: %v", v)
+	}
+	if v, _ := Get(doc, "data.billing.externalId"); v != "order_42" {
+		t.Errorf("Expected externalId order_42, got: %v", v)
+	}
+	if v, _ := Get(doc, "data.billing.id"); v != "bill_1" {
+		t.Errorf("Merging should keep existing fields, got id: %v", v)
+	}
+	if v, _ := Get(doc, "data.note"); v != "hello world" {
+		t.Errorf("Expected plain string value, got: %v", v)
+	}
+}
+
+func TestSet_NotAnObject(t *testing.T) {
+	doc := map[string]any{"event": "billing.paid"}
+
+	if err := Set(doc, "event.name", "x"); err == nil {
+		t.Error("Expected an error when setting a field inside a string")
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"abacatepay-cli/internal/types"
 )
 
-func (s *Service) CreateCheckout(body *types.CreateCheckoutRequest) error {
+func (s *Service) CreateCheckout(body *types.CreateCheckoutRequest, isTrigger bool) (string, error) {
 	var result types.CheckoutResponse
 	err := s.executeRequest(
 		s.Client.R().SetBody(body),
@@ -16,7 +16,11 @@ func (s *Service) CreateCheckout(body *types.CreateCheckoutRequest) error {
 		&result,
 	)
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	if isTrigger {
+		return result.Data.ID, nil
 	}
 
 	output.Print(output.Result{
@@ -30,5 +34,5 @@ func (s *Service) CreateCheckout(body *types.CreateCheckoutRequest) error {
 		Data: result,
 	})
 
-	return nil
+	return result.Data.ID, nil
 }
@@ -1,10 +1,13 @@
 package payments
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 
+	"abacatepay-cli/internal/logger"
 	"abacatepay-cli/internal/output"
 	"abacatepay-cli/internal/style"
 	"abacatepay-cli/internal/types"
@@ -16,6 +19,8 @@ type Service struct {
 	Client  *resty.Client
 	BaseURL string
 	Verbose bool
+
+	ctx context.Context
 }
 
 func New(client *resty.Client, baseURL string, verbose bool) *Service {
@@ -26,8 +31,21 @@ func New(client *resty.Client, baseURL string, verbose bool) *Service {
 	}
 }
 
+// WithContext returns a copy of s whose requests are canceled when ctx is done.
+func (s *Service) WithContext(ctx context.Context) *Service {
+	c := *s
+	c.ctx = ctx
+	return &c
+}
+
+// debug reports whether requests and responses are printed: with --verbose,
+// unless --log-level sets the payments subsystem above debug.
+func (s *Service) debug() bool {
+	return s.Verbose && logger.Subsystem("payments").Enabled(context.Background(), slog.LevelDebug)
+}
+
 func (s *Service) executeRequest(req *resty.Request, method, url string, result any) error {
-	if s.Verbose {
+	if s.debug() {
 		fmt.Printf("Request: %s %s\n", method, url)
 		if body := req.Body; body != nil {
 			if b, ok := body.([]byte); ok {
@@ -44,6 +62,10 @@ func (s *Service) executeRequest(req *resty.Request, method, url string, result
 		fmt.Println()
 	}
 
+	if s.ctx != nil {
+		req.SetContext(s.ctx)
+	}
+
 	var resp *resty.Response
 	var err error
 
@@ -60,7 +82,7 @@ func (s *Service) executeRequest(req *resty.Request, method, url string, result
 		return fmt.Errorf("failed to send request: %w", err)
 	}
 
-	if s.Verbose {
+	if s.debug() {
 		fmt.Printf("Response: %s\n", resp.Status())
 	}
 
@@ -72,7 +94,7 @@ func (s *Service) executeRequest(req *resty.Request, method, url string, result
 		return fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	if s.Verbose {
+	if s.debug() {
 		style.PrintJSON(result)
 		fmt.Println()
 	}
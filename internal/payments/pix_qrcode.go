@@ -33,7 +33,7 @@ func (s *Service) CreatePixQRCode(body *v1.RESTPostCreateQRCodePixBody, isTrigge
 	return result.Data.ID, nil
 }
 
-func (s *Service) CheckPixQRCode(id string) error {
+func (s *Service) CheckPixQRCode(id string, isTrigger bool) (string, error) {
 	var result types.PixResponse
 	err := s.executeRequest(
 		s.Client.R().SetQueryParam("id", id),
@@ -42,19 +42,21 @@ func (s *Service) CheckPixQRCode(id string) error {
 		&result,
 	)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	output.Print(output.Result{
-		Title: "PIX Status Check",
-		Fields: map[string]string{
-			"ID":     id,
-			"Status": result.Data.Status,
-		},
-		Data: result,
-	})
+	if !isTrigger {
+		output.Print(output.Result{
+			Title: "PIX Status Check",
+			Fields: map[string]string{
+				"ID":     id,
+				"Status": result.Data.Status,
+			},
+			Data: result,
+		})
+	}
 
-	return nil
+	return result.Data.Status, nil
 }
 
 func (s *Service) SimulatePixQRCodePayment(id string, isTrigger bool) error {
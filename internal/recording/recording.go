@@ -0,0 +1,114 @@
+// Package recording stores the events received by a listen session in a JSON
+// lines file that can be shared, checked in as a fixture and replayed later.
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+type Entry struct {
+	Event      string    `json:"event"`
+	ID         string    `json:"id,omitempty"`
+	ReceivedAt time.Time `json:"receivedAt"`
+	// Offset is the time since the recording started, used to reproduce the
+	// original pacing on replay.
+	OffsetMs int64 `json:"offsetMs"`
+	// Headers are the signed headers the event was forwarded with.
+	Headers map[string]string `json:"headers,omitempty"`
+	Payload json.RawMessage   `json:"payload"`
+}
+
+func (e Entry) Offset() time.Duration {
+	return time.Duration(e.OffsetMs) * time.Millisecond
+}
+
+type Recorder struct {
+	mu    sync.Mutex
+	file  *os.File
+	enc   *json.Encoder
+	start time.Time
+	count int
+}
+
+func Create(path string) (*Recorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recording %s: %w", path, err)
+	}
+
+	return &Recorder{file: f, enc: json.NewEncoder(f), start: time.Now()}, nil
+}
+
+// Add appends an event. It is safe for concurrent use.
+func (r *Recorder) Add(e Entry) error {
+	if !json.Valid(e.Payload) {
+		return fmt.Errorf("event %s has an invalid JSON payload", e.ID)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e.OffsetMs = e.ReceivedAt.Sub(r.start).Milliseconds()
+
+	if err := r.enc.Encode(e); err != nil {
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	r.count++
+
+	return nil
+}
+
+func (r *Recorder) Count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.count
+}
+
+func (r *Recorder) Close() error {
+	return r.file.Close()
+}
+
+// Load reads a recording, ordered by the time each event was received.
+func Load(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid recording entry: %w", path, line, err)
+		}
+		if len(e.Payload) == 0 {
+			return nil, fmt.Errorf("%s:%d: entry has no payload", path, line)
+		}
+		entries = append(entries, e)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].OffsetMs < entries[j].OffsetMs
+	})
+
+	return entries, nil
+}
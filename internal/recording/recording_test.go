@@ -0,0 +1,98 @@
+package recording
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecorder_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+
+	r, err := Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries := []Entry{
+		{Event: "billing.paid", ID: "evt_1", ReceivedAt: r.start.Add(1500 * time.Millisecond), Headers: map[string]string{"Content-Type": "application/json", "X-Abacate-Signature": "t=1,v1=abc"}, Payload: []byte(`{"id":"evt_1"}`)},
+		{Event: "billing.refunded", ID: "evt_2", ReceivedAt: r.start.Add(3 * time.Second), Payload: []byte(`{"id":"evt_2"}`)},
+	}
+	for _, e := range entries {
+		if err := r.Add(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Add(Entry{ID: "evt_3", ReceivedAt: r.start, Payload: []byte(`{`)}); err == nil {
+		t.Fatal("expected an invalid payload to be rejected")
+	}
+	if r.Count() != 2 {
+		t.Fatalf("expected 2 recorded events, got %d", r.Count())
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", loaded)
+	}
+
+	for i, want := range []time.Duration{1500 * time.Millisecond, 3 * time.Second} {
+		got := loaded[i]
+		if got.ID != entries[i].ID || got.Event != entries[i].Event || string(got.Payload) != string(entries[i].Payload) {
+			t.Fatalf("entry %d: expected %+v, got %+v", i, entries[i], got)
+		}
+		if !maps.Equal(got.Headers, entries[i].Headers) {
+			t.Fatalf("entry %d: expected headers %v, got %v", i, entries[i].Headers, got.Headers)
+		}
+		if !got.ReceivedAt.Equal(entries[i].ReceivedAt) {
+			t.Fatalf("entry %d: expected received at %v, got %v", i, entries[i].ReceivedAt, got.ReceivedAt)
+		}
+		if got.Offset() != want {
+			t.Fatalf("entry %d: expected offset %v, got %v", i, want, got.Offset())
+		}
+	}
+}
+
+func TestLoad_OrdersByOffset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+
+	lines := `{"event":"billing.paid","id":"evt_2","offsetMs":2000,"payload":{}}
+{"event":"billing.paid","id":"evt_1","offsetMs":500,"payload":{}}
+
+{"event":"billing.paid","id":"evt_3","offsetMs":2000,"payload":{}}
+`
+	if err := os.WriteFile(path, []byte(lines), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, e := range entries {
+		ids = append(ids, e.ID)
+	}
+	if len(ids) != 3 || ids[0] != "evt_1" || ids[1] != "evt_2" || ids[2] != "evt_3" {
+		t.Fatalf("expected evt_1, evt_2, evt_3, got %v", ids)
+	}
+}
+
+func TestLoad_RejectsEntryWithoutPayload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+
+	if err := os.WriteFile(path, []byte(`{"event":"billing.paid","offsetMs":0}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatal("expected an entry without payload to be rejected")
+	}
+}
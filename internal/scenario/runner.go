@@ -0,0 +1,386 @@
+package scenario
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"abacatepay-cli/internal/config"
+	"abacatepay-cli/internal/mock"
+	"abacatepay-cli/internal/payload"
+	"abacatepay-cli/internal/payments"
+	"abacatepay-cli/internal/style"
+	"abacatepay-cli/internal/webhook"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	defaultWaitTimeout    = 30 * time.Second
+	defaultConnectTimeout = 15 * time.Second
+)
+
+type Runner struct {
+	Service  *payments.Service
+	Config   *config.Config
+	Client   *resty.Client
+	TxLogger *slog.Logger
+	Secret   string
+}
+
+type StepResult struct {
+	Title    string
+	Action   string
+	Duration time.Duration
+	Output   map[string]any
+	Err      error
+}
+
+// Run executes every step in order and stops at the first failure. The results
+// of the steps that ran are returned together with the error.
+func (r *Runner) Run(ctx context.Context, s *Scenario) ([]StepResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	state := map[string]any{"vars": s.Vars}
+
+	var waiter *webhook.Waiter
+	if s.needsWebhooks() {
+		listener := r.newListener(s.ForwardTo)
+		waiter = webhook.StartWaiter(ctx, listener)
+
+		if err := waiter.Ready(ctx, defaultConnectTimeout); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]StepResult, 0, len(s.Steps))
+
+	for i, step := range s.Steps {
+		result := StepResult{Title: step.Title(i), Action: step.Action}
+		start := time.Now()
+
+		result.Output, result.Err = r.runStep(ctx, s, step, state, waiter)
+		result.Duration = time.Since(start)
+
+		if result.Err == nil {
+			result.Err = checkExpectations(step, result.Output, state)
+		}
+
+		style.LogScenarioStep(result.Err == nil, result.Title, stepDetail(result), result.Duration)
+		results = append(results, result)
+
+		if result.Err != nil {
+			return results, fmt.Errorf("step %q failed: %w", result.Title, result.Err)
+		}
+
+		if step.Save != "" {
+			state[step.Save] = result.Output
+		}
+	}
+
+	return results, nil
+}
+
+func (r *Runner) runStep(ctx context.Context, s *Scenario, step Step, state map[string]any, waiter *webhook.Waiter) (map[string]any, error) {
+	rendered, err := render(step.With, state)
+	if err != nil {
+		return nil, err
+	}
+
+	with, _ := rendered.(map[string]any)
+	if with == nil {
+		with = map[string]any{}
+	}
+
+	switch step.Action {
+	case ActionCreatePix:
+		body := mock.CreatePixQRCodeMock()
+		if err := applyWith(body, with); err != nil {
+			return nil, err
+		}
+
+		id, err := r.Service.CreatePixQRCode(body, true)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"id": id}, nil
+
+	case ActionCreateCheckout:
+		body := mock.CreateCheckoutMock()
+		if err := applyWith(body, with); err != nil {
+			return nil, err
+		}
+
+		id, err := r.Service.CreateCheckout(body, true)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"id": id}, nil
+
+	case ActionSimulatePayment:
+		id, err := requiredArg(with, "id")
+		if err != nil {
+			return nil, err
+		}
+
+		if err := r.Service.SimulatePixQRCodePayment(id, true); err != nil {
+			return nil, err
+		}
+		return map[string]any{"id": id}, nil
+
+	case ActionCheckPix:
+		id, err := requiredArg(with, "id")
+		if err != nil {
+			return nil, err
+		}
+
+		status, err := r.Service.CheckPixQRCode(id, true)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"id": id, "status": status}, nil
+
+	case ActionWaitWebhook:
+		return r.waitWebhook(ctx, with, waiter)
+
+	case ActionDeliverEvent:
+		forwardTo := stringArg(with, "forward_to")
+		if forwardTo == "" {
+			forwardTo = s.ForwardTo
+		}
+		return r.deliverEvent(ctx, with, forwardTo)
+
+	case ActionSleep:
+		d, err := durationArg(with, "duration", time.Second)
+		if err != nil {
+			return nil, err
+		}
+
+		select {
+		case <-time.After(d):
+			return map[string]any{"duration": d.String()}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown action %q", step.Action)
+	}
+}
+
+func (r *Runner) waitWebhook(ctx context.Context, with map[string]any, waiter *webhook.Waiter) (map[string]any, error) {
+	timeout, err := durationArg(with, "timeout", defaultWaitTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	event := stringArg(with, "event")
+	match, _ := with["match"].(map[string]any)
+
+	received, err := waiter.Wait(ctx, timeout, func(rcv webhook.Received) bool {
+		if event != "" && rcv.Delivery.Event != event {
+			return false
+		}
+		if len(match) == 0 {
+			return true
+		}
+
+		doc, err := payload.Decode(rcv.Message)
+		if err != nil {
+			return false
+		}
+		return matches(doc, match)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d := received.Delivery
+	if d.URL != "" && !d.OK() {
+		return nil, deliveryError(d)
+	}
+
+	doc, _ := payload.Decode(received.Message)
+
+	return map[string]any{
+		"id":          d.ID,
+		"event":       d.Event,
+		"status_code": d.StatusCode,
+		"payload":     doc,
+	}, nil
+}
+
+func (r *Runner) deliverEvent(ctx context.Context, with map[string]any, forwardTo string) (map[string]any, error) {
+	event, err := requiredArg(with, "event")
+	if err != nil {
+		return nil, err
+	}
+	if forwardTo == "" {
+		return nil, fmt.Errorf("deliver_event needs forward_to in the step or at the top of the scenario")
+	}
+
+	generated, err := mock.GenerateEvent(event)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := payload.Apply(generated, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, _ := with["set"].(map[string]any)
+	if err := applyOverrides(doc, overrides); err != nil {
+		return nil, err
+	}
+
+	message, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	d, err := r.newListener(forwardTo).Deliver(ctx, message)
+	if err != nil {
+		return nil, err
+	}
+	if !d.OK() {
+		return nil, deliveryError(d)
+	}
+
+	return map[string]any{
+		"id":          d.ID,
+		"event":       d.Event,
+		"status_code": d.StatusCode,
+		"payload":     doc,
+	}, nil
+}
+
+func (r *Runner) newListener(forwardTo string) *webhook.Listener {
+	listener := webhook.NewListener(r.Config, r.Client, forwardTo, r.Config.TokenKey, r.TxLogger)
+	if r.Secret != "" {
+		listener.SetSigningSecret(r.Secret)
+	}
+	return listener
+}
+
+// applyWith overrides fields of an API request body.
+func applyWith(body any, with map[string]any) error {
+	doc, err := payload.Apply(body, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := applyOverrides(doc, with); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to encode request body: %w", err)
+	}
+
+	if err := json.Unmarshal(data, body); err != nil {
+		return fmt.Errorf("invalid request fields: %w", err)
+	}
+
+	return nil
+}
+
+// applyOverrides sets every path in overrides on doc. Templated values arrive as
+// strings, so they are converted back when the field they replace is a number or bool.
+func applyOverrides(doc map[string]any, overrides map[string]any) error {
+	for _, a := range assignments(overrides) {
+		value := a.Value
+		if s, isString := value.(string); isString {
+			switch existing, _ := payload.Get(doc, a.Path); existing.(type) {
+			case json.Number, bool:
+				value = payload.ParseValue(s)
+			}
+		}
+
+		if err := payload.MergeAt(doc, a.Path, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkExpectations(step Step, output map[string]any, state map[string]any) error {
+	if len(step.Expect) == 0 {
+		return nil
+	}
+
+	rendered, err := render(step.Expect, state)
+	if err != nil {
+		return err
+	}
+
+	expect, _ := rendered.(map[string]any)
+	for _, a := range assignments(expect) {
+		actual, ok := payload.Get(output, a.Path)
+		if !ok {
+			return fmt.Errorf("expected %s to be %v, but it is missing", a.Path, a.Value)
+		}
+		if fmt.Sprint(actual) != fmt.Sprint(a.Value) {
+			return fmt.Errorf("expected %s to be %v, got %v", a.Path, a.Value, actual)
+		}
+	}
+
+	return nil
+}
+
+func matches(doc map[string]any, match map[string]any) bool {
+	for _, a := range assignments(match) {
+		actual, ok := payload.Get(doc, a.Path)
+		if !ok || fmt.Sprint(actual) != fmt.Sprint(a.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func assignments(m map[string]any) []payload.Assignment {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make([]payload.Assignment, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, payload.Assignment{Path: k, Value: m[k]})
+	}
+	return out
+}
+
+func requiredArg(with map[string]any, key string) (string, error) {
+	v := stringArg(with, key)
+	if v == "" {
+		return "", fmt.Errorf("missing required argument %q", key)
+	}
+	return v, nil
+}
+
+func deliveryError(d webhook.Delivery) error {
+	if d.Err != nil {
+		return d.Err
+	}
+	return fmt.Errorf("%s was forwarded to %s but the app responded with status %d", d.Event, d.URL, d.StatusCode)
+}
+
+func stepDetail(r StepResult) string {
+	if r.Err != nil {
+		return r.Err.Error()
+	}
+
+	for _, key := range []string{"status", "id", "event"} {
+		if v, ok := r.Output[key]; ok && fmt.Sprint(v) != "" {
+			return fmt.Sprintf("%s=%v", key, v)
+		}
+	}
+	return ""
+}
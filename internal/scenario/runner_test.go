@@ -0,0 +1,74 @@
+package scenario
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"abacatepay-cli/internal/payload"
+)
+
+func TestApplyOverrides_CoercesTemplatedValues(t *testing.T) {
+	doc, err := payload.Decode([]byte(`{"data":{"billing":{"id":"bill_1","amount":100,"devMode":true}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = applyOverrides(doc, map[string]any{
+		"data.billing.amount":  "5000",
+		"data.billing.devMode": "false",
+		"data.billing.id":      "1234",
+		"data.billing.note":    "42",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	billing := doc["data"].(map[string]any)["billing"].(map[string]any)
+	if billing["amount"] != json.Number("5000") {
+		t.Errorf("expected amount to stay a number, got %#v", billing["amount"])
+	}
+	if billing["devMode"] != false {
+		t.Errorf("expected devMode to stay a bool, got %#v", billing["devMode"])
+	}
+	if billing["id"] != "1234" {
+		t.Errorf("expected id to stay a string, got %#v", billing["id"])
+	}
+	if billing["note"] != "42" {
+		t.Errorf("expected a new field to be set as given, got %#v", billing["note"])
+	}
+}
+
+func TestCheckExpectations(t *testing.T) {
+	output := map[string]any{
+		"id":          "pix_1",
+		"status":      "PAID",
+		"status_code": 200,
+		"payload":     map[string]any{"data": map[string]any{"amount": json.Number("5000")}},
+	}
+	state := map[string]any{"vars": map[string]any{"amount": 5000}}
+
+	pass := Step{Expect: map[string]any{
+		"status":              "PAID",
+		"status_code":         200,
+		"payload.data.amount": "{{ .vars.amount }}",
+	}}
+	if err := checkExpectations(pass, output, state); err != nil {
+		t.Fatalf("expected expectations to pass, got %v", err)
+	}
+
+	tests := []struct {
+		expect  map[string]any
+		wantErr string
+	}{
+		{map[string]any{"status": "EXPIRED"}, "expected status to be EXPIRED, got PAID"},
+		{map[string]any{"payload.data.refunded": true}, "expected payload.data.refunded to be true, but it is missing"},
+		{map[string]any{"id": "{{ .pix.id }}"}, "failed to render"},
+	}
+	for _, tt := range tests {
+		err := checkExpectations(Step{Expect: tt.expect}, output, state)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Package scenario runs YAML scripts that chain API calls, webhook waits and
+// assertions, passing values from one step to the next through templates.
+package scenario
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ActionCreatePix       = "create_pix"
+	ActionCreateCheckout  = "create_checkout"
+	ActionSimulatePayment = "simulate_payment"
+	ActionCheckPix        = "check_pix"
+	ActionWaitWebhook     = "wait_webhook"
+	ActionDeliverEvent    = "deliver_event"
+	ActionSleep           = "sleep"
+)
+
+var Actions = []string{
+	ActionCreatePix,
+	ActionCreateCheckout,
+	ActionSimulatePayment,
+	ActionCheckPix,
+	ActionWaitWebhook,
+	ActionDeliverEvent,
+	ActionSleep,
+}
+
+// Scenario is the root of a scenario file:
+//
+//	name: checkout-happy-path
+//	forward_to: http://localhost:3000/webhooks/abacatepay
+//	vars:
+//	  amount: 5000
+//	steps:
+//	  - action: create_pix
+//	    with: { amount: "{{ .vars.amount }}" }
+//	    save: pix
+//	  - action: simulate_payment
+//	    with: { id: "{{ .pix.id }}" }
+//	  - action: wait_webhook
+//	    with: { event: billing.paid, timeout: 30s }
+//	  - action: check_pix
+//	    with: { id: "{{ .pix.id }}" }
+//	    expect: { status: PAID }
+type Scenario struct {
+	Name      string         `yaml:"name"`
+	ForwardTo string         `yaml:"forward_to"`
+	Vars      map[string]any `yaml:"vars"`
+	Steps     []Step         `yaml:"steps"`
+}
+
+type Step struct {
+	Name   string         `yaml:"name"`
+	Action string         `yaml:"action"`
+	With   map[string]any `yaml:"with"`
+	Save   string         `yaml:"save"`
+	Expect map[string]any `yaml:"expect"`
+}
+
+func Load(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+
+	var s Scenario
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario %s: %w", path, err)
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario %s: %w", path, err)
+	}
+
+	return &s, nil
+}
+
+func (s *Scenario) Validate() error {
+	if len(s.Steps) == 0 {
+		return fmt.Errorf("no steps defined")
+	}
+
+	for i, step := range s.Steps {
+		if !isAction(step.Action) {
+			return fmt.Errorf("step %d: unknown action %q (valid: %s)", i+1, step.Action, strings.Join(Actions, ", "))
+		}
+		if step.Save == "vars" {
+			return fmt.Errorf("step %d: 'vars' is reserved and can't be used in save", i+1)
+		}
+	}
+
+	return nil
+}
+
+// RequiresAuth reports whether any step talks to the AbacatePay API or WebSocket.
+// Scenarios made only of local steps (deliver_event, sleep) run offline.
+func (s *Scenario) RequiresAuth() bool {
+	for _, step := range s.Steps {
+		switch step.Action {
+		case ActionDeliverEvent, ActionSleep:
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Scenario) needsWebhooks() bool {
+	for _, step := range s.Steps {
+		if step.Action == ActionWaitWebhook {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Step) Title(index int) string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return fmt.Sprintf("%d. %s", index+1, s.Action)
+}
+
+func isAction(action string) bool {
+	for _, a := range Actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// render expands templates in every string of v against the scenario state.
+func render(v any, state map[string]any) (any, error) {
+	switch val := v.(type) {
+	case string:
+		if !strings.Contains(val, "{{") {
+			return val, nil
+		}
+
+		tmpl, err := template.New("value").Option("missingkey=error").Parse(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template %q: %w", val, err)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, state); err != nil {
+			return nil, fmt.Errorf("failed to render %q: %w", val, err)
+		}
+		return buf.String(), nil
+
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, item := range val {
+			rendered, err := render(item, state)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = rendered
+		}
+		return out, nil
+
+	case []any:
+		out := make([]any, 0, len(val))
+		for _, item := range val {
+			rendered, err := render(item, state)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, rendered)
+		}
+		return out, nil
+
+	default:
+		return v, nil
+	}
+}
+
+func stringArg(with map[string]any, key string) string {
+	v, ok := with[key]
+	if !ok || v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func durationArg(with map[string]any, key string, fallback time.Duration) (time.Duration, error) {
+	raw := stringArg(with, key)
+	if raw == "" {
+		return fallback, nil
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", key, raw, err)
+	}
+	return d, nil
+}
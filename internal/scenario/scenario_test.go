@@ -0,0 +1,87 @@
+package scenario
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	state := map[string]any{
+		"vars": map[string]any{"amount": 5000},
+		"pix":  map[string]any{"id": "pix_1"},
+	}
+
+	got, err := render(map[string]any{
+		"id":    "{{ .pix.id }}",
+		"items": []any{"{{ .vars.amount }}", 3},
+		"plain": "no template",
+	}, state)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := got.(map[string]any)
+	items := out["items"].([]any)
+	if out["id"] != "pix_1" || items[0] != "5000" || items[1] != 3 || out["plain"] != "no template" {
+		t.Fatalf("unexpected render result %+v", out)
+	}
+}
+
+func TestRender_MissingVariable(t *testing.T) {
+	state := map[string]any{"vars": map[string]any{}}
+
+	for _, tmpl := range []string{"{{ .pix.id }}", "{{ .vars.amount }}"} {
+		if _, err := render(tmpl, state); err == nil {
+			t.Errorf("expected an error rendering %q", tmpl)
+		}
+	}
+
+	if _, err := render("{{ .vars.amount", state); err == nil || !strings.Contains(err.Error(), "invalid template") {
+		t.Errorf("expected a parse error, got %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		steps   []Step
+		wantErr string
+	}{
+		{"no steps", nil, "no steps defined"},
+		{"unknown action", []Step{{Action: ActionSleep}, {Action: "refund"}}, `step 2: unknown action "refund"`},
+		{"reserved save", []Step{{Action: ActionCreatePix, Save: "vars"}}, "step 1: 'vars' is reserved"},
+		{"valid", []Step{{Action: ActionCreatePix, Save: "pix"}}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&Scenario{Steps: tt.steps}).Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoad_Examples(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "examples", "scenarios", "*.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no example scenarios found")
+	}
+
+	for _, file := range files {
+		if _, err := Load(file); err != nil {
+			t.Errorf("%s: %v", file, err)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Package schema derives JSON Schemas (draft 2020-12) from the Go event types and
+// validates captured payloads against them.
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+type Schema struct {
+	Schema      string             `json:"$schema,omitempty"`
+	ID          string             `json:"$id,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Type        any                `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Const       any                `json:"const,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	// AdditionalProperties is only set for maps; structs leave it open so new
+	// fields added by AbacatePay don't break consumers (see Validate's strict mode).
+	AdditionalProperties *Schema `json:"additionalProperties,omitempty"`
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// ForEvent builds the schema of an event from a sample value of its Go type,
+// pinning the "event" property to the event name.
+func ForEvent(name, description string, sample any) *Schema {
+	s := For(reflect.TypeOf(sample))
+	s.Schema = Draft
+	s.ID = "https://abacatepay.com/schemas/events/" + name + ".json"
+	s.Title = name
+	s.Description = description
+
+	if prop, ok := s.Properties["event"]; ok {
+		prop.Const = name
+	}
+
+	return s
+}
+
+func For(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return forStruct(t)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string"}
+		}
+		return &Schema{Type: "array", Items: For(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: For(t.Elem())}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	default:
+		return &Schema{}
+	}
+}
+
+func forStruct(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, omitEmpty, skip := jsonName(field)
+		if skip {
+			continue
+		}
+
+		prop := For(field.Type)
+		if field.Type.Kind() == reflect.Pointer && !omitEmpty {
+			if typ, ok := prop.Type.(string); ok {
+				prop.Type = []string{typ, "null"}
+			}
+		}
+
+		s.Properties[name] = prop
+		if !omitEmpty && field.Type.Kind() != reflect.Pointer {
+			s.Required = append(s.Required, name)
+		}
+	}
+
+	return s
+}
+
+func jsonName(field reflect.StructField) (name string, omitEmpty, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == "omitempty" || opt == "omitzero" {
+			omitEmpty = true
+		}
+	}
+
+	return name, omitEmpty, false
+}
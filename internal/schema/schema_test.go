@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"testing"
+
+	"abacatepay-cli/internal/mock"
+	"abacatepay-cli/internal/payload"
+)
+
+func TestValidate_CatalogSamplesMatchTheirSchema(t *testing.T) {
+	for _, def := range mock.Events() {
+		sample := def.Generate()
+
+		doc, err := payload.Apply(sample, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", def.Name, err)
+		}
+
+		s := ForEvent(def.Name, def.Description, sample)
+		if errs := s.Validate(doc, true); len(errs) > 0 {
+			t.Errorf("%s: sample doesn't match its schema: %v", def.Name, errs)
+		}
+	}
+}
+
+func TestValidate_ReportsDrift(t *testing.T) {
+	sample := mock.MockBillingPaidEvent()
+	s := ForEvent("billing.paid", "", sample)
+
+	doc, err := payload.Apply(sample, []payload.Assignment{
+		{Path: "event", Value: "billing.created"},
+		{Path: "data.billing.amount", Value: "5000"},
+		{Path: "data.billing.newField", Value: true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	delete(doc, "devMode")
+
+	if errs := s.Validate(doc, false); len(errs) != 3 {
+		t.Fatalf("expected 3 errors without strict mode, got %v", errs)
+	}
+
+	if errs := s.Validate(doc, true); len(errs) != 4 {
+		t.Fatalf("expected 4 errors in strict mode, got %v", errs)
+	}
+}
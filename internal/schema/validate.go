@@ -0,0 +1,140 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type ValidationError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// Validate checks a decoded payload (decoded with UseNumber) against the schema.
+// In strict mode properties the schema doesn't know about are reported too,
+// which is how contract drift in AbacatePay payloads shows up.
+func (s *Schema) Validate(doc any, strict bool) []ValidationError {
+	var errs []ValidationError
+	s.validate("$", doc, strict, &errs)
+	return errs
+}
+
+func (s *Schema) validate(path string, value any, strict bool, errs *[]ValidationError) {
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.Const != nil && fmt.Sprint(value) != fmt.Sprint(s.Const) {
+		fail("expected %v, got %v", s.Const, value)
+		return
+	}
+
+	if !s.allows(value) {
+		fail("expected %s, got %s", s.typeNames(), typeOf(value))
+		return
+	}
+
+	switch v := value.(type) {
+	case string:
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, v); err != nil {
+				fail("expected an RFC 3339 date-time, got %q", v)
+			}
+		}
+
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				*errs = append(*errs, ValidationError{Path: path + "." + name, Message: "required property is missing"})
+			}
+		}
+
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			child := path + "." + k
+			if prop, ok := s.Properties[k]; ok {
+				prop.validate(child, v[k], strict, errs)
+			} else if s.AdditionalProperties != nil {
+				s.AdditionalProperties.validate(child, v[k], strict, errs)
+			} else if strict && s.Properties != nil {
+				*errs = append(*errs, ValidationError{Path: child, Message: "property is not part of the schema"})
+			}
+		}
+
+	case []any:
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, strict, errs)
+			}
+		}
+	}
+}
+
+func (s *Schema) allows(value any) bool {
+	types := s.types()
+	if len(types) == 0 {
+		return true
+	}
+
+	actual := typeOf(value)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Schema) types() []string {
+	switch t := s.Type.(type) {
+	case string:
+		return []string{t}
+	case []string:
+		return t
+	default:
+		return nil
+	}
+}
+
+func (s *Schema) typeNames() string {
+	return strings.Join(s.types(), " or ")
+}
+
+func typeOf(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,115 @@
+// Package snippet renders signed webhook requests as ready-to-run commands and code.
+package snippet
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Header struct {
+	Name  string
+	Value string
+}
+
+type Request struct {
+	URL     string
+	Headers []Header
+	Body    []byte
+}
+
+var Formats = []string{"curl", "httpie", "fetch", "go"}
+
+func Render(format string, req Request) (string, error) {
+	switch format {
+	case "curl":
+		return renderCurl(req), nil
+	case "httpie":
+		return renderHTTPie(req), nil
+	case "fetch":
+		return renderFetch(req), nil
+	case "go":
+		return renderGo(req), nil
+	default:
+		return "", fmt.Errorf("invalid snippet format: %s (valid: %s)", format, strings.Join(Formats, ", "))
+	}
+}
+
+func renderCurl(req Request) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "curl -X POST %s", shellQuote(req.URL))
+	for _, h := range req.Headers {
+		fmt.Fprintf(&b, " \\\n  -H %s", shellQuote(h.Name+": "+h.Value))
+	}
+	fmt.Fprintf(&b, " \\\n  --data-raw %s", shellQuote(string(req.Body)))
+
+	return b.String()
+}
+
+func renderHTTPie(req Request) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "printf '%%s' %s | http POST %s", shellQuote(string(req.Body)), shellQuote(req.URL))
+	for _, h := range req.Headers {
+		fmt.Fprintf(&b, " \\\n  %s", shellQuote(h.Name+":"+h.Value))
+	}
+
+	return b.String()
+}
+
+func renderFetch(req Request) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "const response = await fetch(%s, {\n", jsString(req.URL))
+	b.WriteString("  method: \"POST\",\n")
+	b.WriteString("  headers: {\n")
+	for _, h := range req.Headers {
+		fmt.Fprintf(&b, "    %s: %s,\n", jsString(h.Name), jsString(h.Value))
+	}
+	b.WriteString("  },\n")
+	fmt.Fprintf(&b, "  body: %s,\n", jsString(string(req.Body)))
+	b.WriteString("});\n\n")
+	b.WriteString("console.log(response.status, await response.text());")
+
+	return b.String()
+}
+
+func renderGo(req Request) string {
+	var b strings.Builder
+
+	b.WriteString("package main\n\n")
+	b.WriteString("import (\n\t\"fmt\"\n\t\"io\"\n\t\"net/http\"\n\t\"strings\"\n)\n\n")
+	b.WriteString("func main() {\n")
+	fmt.Fprintf(&b, "\tbody := %s\n\n", goString(string(req.Body)))
+	fmt.Fprintf(&b, "\treq, err := http.NewRequest(http.MethodPost, %s, strings.NewReader(body))\n", strconv.Quote(req.URL))
+	b.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n\n")
+	for _, h := range req.Headers {
+		fmt.Fprintf(&b, "\treq.Header.Set(%s, %s)\n", strconv.Quote(h.Name), strconv.Quote(h.Value))
+	}
+	b.WriteString("\n\tresp, err := http.DefaultClient.Do(req)\n")
+	b.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n")
+	b.WriteString("\tdefer resp.Body.Close()\n\n")
+	b.WriteString("\trespBody, _ := io.ReadAll(resp.Body)\n")
+	b.WriteString("\tfmt.Println(resp.Status, string(respBody))\n")
+	b.WriteString("}")
+
+	return b.String()
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func jsString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+func goString(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
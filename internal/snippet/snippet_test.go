@@ -0,0 +1,192 @@
+package snippet
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"abacatepay-cli/internal/crypto"
+)
+
+// trickyBody has the characters each format has to escape: single quotes for
+// the shell, newlines, double quotes and a backtick for Go raw strings.
+const trickyBody = "{\"data\":{\"note\":\"it's \\\"paid\\\"\",\n\"memo\":\"line 1\nline 2 `x`\"}}"
+
+// testBodies also covers a multi-line body without a backtick, which the Go
+// snippet writes as a raw string.
+var testBodies = []string{`{"event":"billing.paid"}`, "{\n  \"note\": \"it's paid\"\n}", trickyBody}
+
+func testRequest(t *testing.T, body string) Request {
+	t.Helper()
+
+	timestamp := int64(1767225600)
+	signature := crypto.SignWebhookPayload("whsec_test", timestamp, []byte(body))
+
+	return Request{
+		URL: "http://localhost:3000/webhooks?src='cli'",
+		Headers: []Header{
+			{Name: "Content-Type", Value: "application/json"},
+			{Name: crypto.SignatureHeader, Value: crypto.FormatSignatureHeader(timestamp, signature)},
+		},
+		Body: []byte(body),
+	}
+}
+
+// runShell runs a shell snippet with its command replaced by a function that
+// prints stdin and then each argument, NUL separated.
+func runShell(t *testing.T, command, script string) []string {
+	t.Helper()
+
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not available")
+	}
+
+	fn := command + `() { cat; printf '\0'; printf '%s\0' "$@"; }` + "\n"
+	out, err := exec.Command(bash, "-c", fn+script).Output()
+	if err != nil {
+		t.Fatalf("snippet failed to run: %v\n%s", err, script)
+	}
+
+	return strings.Split(strings.TrimSuffix(string(out), "\x00"), "\x00")
+}
+
+func TestRender_Curl(t *testing.T) {
+	for _, body := range testBodies {
+		req := testRequest(t, body)
+
+		out, err := Render("curl", req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// curl isn't fed stdin, so the first field is empty.
+		got := runShell(t, "curl", out+" </dev/null")
+		want := []string{"", "-X", "POST", req.URL,
+			"-H", "Content-Type: application/json",
+			"-H", crypto.SignatureHeader + ": " + req.Headers[1].Value,
+			"--data-raw", body,
+		}
+		if !slices.Equal(got, want) {
+			t.Fatalf("expected arguments %q, got %q", want, got)
+		}
+	}
+}
+
+func TestRender_HTTPie(t *testing.T) {
+	for _, body := range testBodies {
+		req := testRequest(t, body)
+
+		out, err := Render("httpie", req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := runShell(t, "http", out)
+		want := []string{body, "POST", req.URL,
+			"Content-Type:application/json",
+			crypto.SignatureHeader + ":" + req.Headers[1].Value,
+		}
+		if !slices.Equal(got, want) {
+			t.Fatalf("expected stdin and arguments %q, got %q", want, got)
+		}
+	}
+}
+
+func TestRender_Fetch(t *testing.T) {
+	req := testRequest(t, trickyBody)
+
+	out, err := Render("fetch", req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fields := map[string]string{}
+	for line := range strings.SplitSeq(out, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ": ")
+		if !ok || !strings.HasSuffix(value, ",") {
+			continue
+		}
+
+		var name, s string
+		if err := json.Unmarshal([]byte(key), &name); err != nil {
+			name = key
+		}
+		if err := json.Unmarshal([]byte(strings.TrimSuffix(value, ",")), &s); err != nil {
+			t.Fatalf("line %q isn't a valid string literal: %v", line, err)
+		}
+		fields[name] = s
+	}
+
+	if fields["body"] != trickyBody {
+		t.Fatalf("expected body %q, got %q", trickyBody, fields["body"])
+	}
+	if got := fields[crypto.SignatureHeader]; got != req.Headers[1].Value {
+		t.Fatalf("expected signature header %q, got %q", req.Headers[1].Value, got)
+	}
+}
+
+func TestRender_Go(t *testing.T) {
+	for _, body := range testBodies {
+		req := testRequest(t, body)
+
+		out, err := Render("go", req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), "main.go", out, 0)
+		if err != nil {
+			t.Fatalf("snippet isn't valid Go: %v\n%s", err, out)
+		}
+
+		var gotBody string
+		headers := map[string]string{}
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.AssignStmt:
+				if id, ok := node.Lhs[0].(*ast.Ident); ok && id.Name == "body" {
+					gotBody = unquote(t, node.Rhs[0])
+				}
+			case *ast.CallExpr:
+				if sel, ok := node.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Set" && len(node.Args) == 2 {
+					headers[unquote(t, node.Args[0])] = unquote(t, node.Args[1])
+				}
+			}
+			return true
+		})
+
+		if gotBody != body {
+			t.Fatalf("expected body %q, got %q", body, gotBody)
+		}
+		if got := headers[crypto.SignatureHeader]; got != req.Headers[1].Value {
+			t.Fatalf("expected signature header %q, got %q", req.Headers[1].Value, got)
+		}
+	}
+}
+
+func TestRender_InvalidFormat(t *testing.T) {
+	if _, err := Render("wget", testRequest(t, "{}")); err == nil {
+		t.Fatal("expected an error for an unknown format")
+	}
+}
+
+func unquote(t *testing.T, expr ast.Expr) string {
+	t.Helper()
+
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		t.Fatalf("expected a string literal, got %T", expr)
+	}
+	s, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
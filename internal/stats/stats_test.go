@@ -0,0 +1,65 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	ms := time.Millisecond
+
+	hundred := make([]time.Duration, 100)
+	for i := range hundred {
+		// Reversed, so Summarize has to sort them.
+		hundred[i] = time.Duration(100-i) * ms
+	}
+
+	tests := []struct {
+		name    string
+		samples []time.Duration
+		want    Latency
+	}{
+		{"empty", nil, Latency{}},
+		{"one sample", []time.Duration{7 * ms}, Latency{Count: 1, Min: 7 * ms, Mean: 7 * ms, P50: 7 * ms, P95: 7 * ms, P99: 7 * ms, Max: 7 * ms}},
+		{"two samples", []time.Duration{30 * ms, 10 * ms}, Latency{Count: 2, Min: 10 * ms, Mean: 20 * ms, P50: 10 * ms, P95: 30 * ms, P99: 30 * ms, Max: 30 * ms}},
+		{"hundred samples", hundred, Latency{Count: 100, Min: ms, Mean: 50500 * time.Microsecond, P50: 50 * ms, P95: 95 * ms, P99: 99 * ms, Max: 100 * ms}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Summarize(tt.samples); got != tt.want {
+				t.Fatalf("Summarize() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarize_DoesNotReorderSamples(t *testing.T) {
+	samples := []time.Duration{3, 1, 2}
+	Summarize(samples)
+	if samples[0] != 3 || samples[1] != 1 || samples[2] != 2 {
+		t.Fatalf("samples were modified: %v", samples)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []time.Duration{1, 2, 3, 4}
+
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, 1},
+		{25, 1},
+		{50, 2},
+		{51, 3},
+		{100, 4},
+		{150, 4},
+	}
+
+	for _, tt := range tests {
+		if got := percentile(sorted, tt.p); got != tt.want {
+			t.Errorf("percentile(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+}
@@ -3,7 +3,9 @@ package style
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
+	"os"
 	"sort"
 	"strings"
 	"time"
@@ -187,9 +189,19 @@ func PrintVerifyError(expected, received string) {
 	fmt.Println(BoxStyle.BorderForeground(Palette.SoftRed).Render(sb.String()))
 }
 
+// logOutput receives the live lines of webhook traffic and scenario progress
+// printed by the Log* functions below.
+var logOutput io.Writer = os.Stdout
+
+// SetLogOutput sends the live webhook lines to w, e.g. to stderr when stdout is
+// reserved for JSON output.
+func SetLogOutput(w io.Writer) {
+	logOutput = w
+}
+
 func LogWebhookReceived(event, id string) {
 	timestamp := time.Now().Format("15:04:05")
-	fmt.Printf("%s  %s %s [%s]\n",
+	fmt.Fprintf(logOutput, "%s  %s %s [%s]\n",
 		lipgloss.NewStyle().Foreground(Palette.Gray).Render(timestamp),
 		lipgloss.NewStyle().Foreground(Palette.Green).Bold(true).Render("-->"),
 		lipgloss.NewStyle().Bold(true).Render(event),
@@ -207,7 +219,7 @@ func LogWebhookForwarded(statusCode int, statusText, event string) {
 	codeStyle := lipgloss.NewStyle().Foreground(codeColor).Bold(true)
 	bracketStyle := lipgloss.NewStyle().Foreground(Palette.Gray)
 
-	fmt.Printf("%s  %s %s%s%s %s\n",
+	fmt.Fprintf(logOutput, "%s  %s %s%s%s %s\n",
 		lipgloss.NewStyle().Foreground(Palette.Gray).Render(timestamp),
 		lipgloss.NewStyle().Foreground(Palette.Green).Bold(true).Render("<--"),
 		bracketStyle.Render("["),
@@ -217,8 +229,61 @@ func LogWebhookForwarded(statusCode int, statusText, event string) {
 	)
 }
 
+// LogEntryLine prints a line of the transaction log in the style of the listen
+// output: received events with -->, forwards with <-- and their status code.
+// A status code of 0 is a forward that got no response.
+func LogEntryLine(at time.Time, received bool, statusCode int, event, id, detail string) {
+	gray := lipgloss.NewStyle().Foreground(Palette.Gray)
+	arrow := lipgloss.NewStyle().Foreground(Palette.Green).Bold(true)
+
+	if received {
+		fmt.Printf("%s  %s %s %s %s\n",
+			gray.Render(at.Local().Format("15:04:05")),
+			arrow.Render("-->"),
+			lipgloss.NewStyle().Bold(true).Render(event),
+			gray.Render("["+id+"]"),
+			gray.Render(detail),
+		)
+		return
+	}
+
+	codeColor := Palette.Green
+	if statusCode < 200 || statusCode >= 300 {
+		codeColor = Palette.SoftRed
+	}
+	code := "ERR"
+	if statusCode > 0 {
+		code = fmt.Sprintf("%d", statusCode)
+	}
+
+	fmt.Printf("%s  %s %s%s%s %s %s %s\n",
+		gray.Render(at.Local().Format("15:04:05")),
+		arrow.Render("<--"),
+		gray.Render("["),
+		lipgloss.NewStyle().Foreground(codeColor).Bold(true).Render(code),
+		gray.Render("]"),
+		lipgloss.NewStyle().Bold(true).Render(event),
+		gray.Render("["+id+"]"),
+		gray.Render(detail),
+	)
+}
+
+func LogScenarioStep(ok bool, title, detail string, duration time.Duration) {
+	mark := lipgloss.NewStyle().Foreground(Palette.Green).Bold(true).Render("✔")
+	if !ok {
+		mark = lipgloss.NewStyle().Foreground(Palette.SoftRed).Bold(true).Render("✘")
+	}
+
+	fmt.Fprintf(logOutput, "%s %s %s %s\n",
+		mark,
+		lipgloss.NewStyle().Bold(true).Render(title),
+		lipgloss.NewStyle().Foreground(Palette.Gray).Render(fmt.Sprintf("(%dms)", duration.Milliseconds())),
+		detail,
+	)
+}
+
 func LogSigningSecret(secret string) {
-	fmt.Printf("%s Your webhook signing secret is %s\n",
+	fmt.Fprintf(logOutput, "%s Your webhook signing secret is %s\n",
 		lipgloss.NewStyle().Foreground(Palette.Green).Bold(true).Render(">"),
 		lipgloss.NewStyle().Bold(true).Render(secret),
 	)
@@ -2,6 +2,21 @@ package types
 
 import "time"
 
+type Event interface {
+	EventID() string
+	EventType() string
+}
+
+type BillingCreatedEvent struct {
+	ID   string `json:"id"`
+	Data struct {
+		Billing  *EventBilling  `json:"billing,omitempty"`
+		Customer *EventCustomer `json:"customer,omitempty"`
+	} `json:"data"`
+	DevMode bool   `json:"devMode"`
+	Event   string `json:"event"`
+}
+
 type BillingPaidEvent struct {
 	ID   string `json:"id"`
 	Data struct {
@@ -12,6 +27,26 @@ type BillingPaidEvent struct {
 	Event   string `json:"event"`
 }
 
+type BillingRefundedEvent struct {
+	ID   string `json:"id"`
+	Data struct {
+		Billing *EventBilling `json:"billing,omitempty"`
+		Refund  *EventRefund  `json:"refund,omitempty"`
+	} `json:"data"`
+	DevMode bool   `json:"devMode"`
+	Event   string `json:"event"`
+}
+
+type BillingDisputedEvent struct {
+	ID   string `json:"id"`
+	Data struct {
+		Billing *EventBilling `json:"billing,omitempty"`
+		Dispute *EventDispute `json:"dispute,omitempty"`
+	} `json:"data"`
+	DevMode bool   `json:"devMode"`
+	Event   string `json:"event"`
+}
+
 type PayoutEvent struct {
 	ID   string `json:"id"`
 	Data struct {
@@ -21,6 +56,25 @@ type PayoutEvent struct {
 	Event   string `json:"event"`
 }
 
+type WithdrawEvent struct {
+	ID   string `json:"id"`
+	Data struct {
+		Transaction *EventTransaction `json:"transaction,omitempty"`
+	} `json:"data"`
+	DevMode bool   `json:"devMode"`
+	Event   string `json:"event"`
+}
+
+type SubscriptionEvent struct {
+	ID   string `json:"id"`
+	Data struct {
+		Subscription *EventSubscription `json:"subscription,omitempty"`
+		Customer     *EventCustomer     `json:"customer,omitempty"`
+	} `json:"data"`
+	DevMode bool   `json:"devMode"`
+	Event   string `json:"event"`
+}
+
 type EventPayment struct {
 	Amount int    `json:"amount"`
 	Fee    int    `json:"fee"`
@@ -35,6 +89,29 @@ type EventBilling struct {
 	Status     string `json:"status"`
 }
 
+type EventCustomer struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	TaxID     string `json:"taxId"`
+	Cellphone string `json:"cellphone"`
+}
+
+type EventRefund struct {
+	ID        string    `json:"id"`
+	Amount    int       `json:"amount"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type EventDispute struct {
+	ID        string    `json:"id"`
+	Amount    int       `json:"amount"`
+	Reason    string    `json:"reason"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
 type EventTransaction struct {
 	ID          string    `json:"id"`
 	Status      string    `json:"status"`
@@ -47,3 +124,30 @@ type EventTransaction struct {
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
 }
+
+type EventSubscription struct {
+	ID              string     `json:"id"`
+	Status          string     `json:"status"`
+	Amount          int        `json:"amount"`
+	Frequency       string     `json:"frequency"`
+	Method          string     `json:"method"`
+	ExternalID      string     `json:"externalId"`
+	NextBillingDate *time.Time `json:"nextBillingDate,omitempty"`
+	CanceledAt      *time.Time `json:"canceledAt,omitempty"`
+	CreatedAt       time.Time  `json:"createdAt"`
+}
+
+func (e *BillingCreatedEvent) EventID() string    { return e.ID }
+func (e *BillingCreatedEvent) EventType() string  { return e.Event }
+func (e *BillingPaidEvent) EventID() string       { return e.ID }
+func (e *BillingPaidEvent) EventType() string     { return e.Event }
+func (e *BillingRefundedEvent) EventID() string   { return e.ID }
+func (e *BillingRefundedEvent) EventType() string { return e.Event }
+func (e *BillingDisputedEvent) EventID() string   { return e.ID }
+func (e *BillingDisputedEvent) EventType() string { return e.Event }
+func (e *PayoutEvent) EventID() string            { return e.ID }
+func (e *PayoutEvent) EventType() string          { return e.Event }
+func (e *WithdrawEvent) EventID() string          { return e.ID }
+func (e *WithdrawEvent) EventType() string        { return e.Event }
+func (e *SubscriptionEvent) EventID() string      { return e.ID }
+func (e *SubscriptionEvent) EventType() string    { return e.Event }
@@ -57,3 +57,21 @@ type TokenResponse struct {
 type APIError struct {
 	Message string `json:"error"`
 }
+
+type Address struct {
+	Street       string `json:"street"`
+	Number       string `json:"number"`
+	Neighborhood string `json:"neighborhood"`
+	City         string `json:"city"`
+	State        string `json:"state"`
+	ZipCode      string `json:"zipCode"`
+}
+
+type CustomerRecord struct {
+	Name      string   `json:"name"`
+	Email     string   `json:"email"`
+	Cellphone string   `json:"cellphone"`
+	TaxID     string   `json:"taxId"`
+	TaxIDType string   `json:"taxIdType"`
+	Address   *Address `json:"address"`
+}
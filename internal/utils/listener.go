@@ -11,12 +11,13 @@ import (
 )
 
 func StartListener(params *StartListenerParams) error {
-	txLogger, err := SetupTransactionLogger()
+	txLogger, err := SetupTransactionLogger(params.LogTags)
 	if err != nil {
 		return fmt.Errorf("failed to initialize transaction logger: %w", err)
 	}
 
 	listener := webhook.NewListener(params.Config, params.Client, params.ForwardURL, params.Token, txLogger)
+	listener.SetHooks(params.Hooks)
 
 	fmt.Fprintln(os.Stderr)
 	if params.Mock {
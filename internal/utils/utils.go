@@ -11,6 +11,7 @@ import (
 	"abacatepay-cli/internal/config"
 	"abacatepay-cli/internal/logger"
 	"abacatepay-cli/internal/store"
+	"abacatepay-cli/internal/webhook"
 
 	"github.com/go-resty/resty/v2"
 )
@@ -24,21 +25,35 @@ type StartListenerParams struct {
 	ForwardURL string
 	Version    string
 	Mock       bool
+	Hooks      webhook.Hooks
+	LogTags    logger.Tags
 }
 
 type Dependencies struct {
 	Config *config.Config
 	Client *resty.Client
 	Store  store.TokenStore
+	// Profile is the authenticated profile, empty when running offline.
+	Profile string
 }
 
-func SetupTransactionLogger() (*slog.Logger, error) {
+// LogTags are the tags of the transaction log lines written with deps. Without
+// an authenticated profile nothing went through the API, so the events are
+// tagged offline rather than with the environment of the config.
+func (d *Dependencies) LogTags() logger.Tags {
+	if d.Profile == "" {
+		return logger.Tags{Env: config.EnvOffline}
+	}
+	return logger.Tags{Profile: d.Profile, Env: d.Config.Environment}
+}
+
+func SetupTransactionLogger(tags logger.Tags) (*slog.Logger, error) {
 	logCfg, err := logger.DefaultConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure logger: %w", err)
 	}
 
-	return logger.NewTransactionLogger(logCfg)
+	return logger.NewTransactionLogger(logCfg, tags)
 }
 
 func GetConfig(local bool) *config.Config {
@@ -89,5 +104,6 @@ func SetupClient(local, verbose bool) (*Dependencies, error) {
 	}
 
 	deps.Client.SetAuthToken(token)
+	deps.Profile = activeProfile
 	return deps, nil
 }
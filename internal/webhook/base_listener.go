@@ -2,12 +2,12 @@ package webhook
 
 import (
 	"context"
-	"log/slog"
 	"net/http"
 	"sync"
 	"time"
 
 	"abacatepay-cli/internal/config"
+	"abacatepay-cli/internal/logger"
 	"abacatepay-cli/internal/ws"
 
 	"github.com/gorilla/websocket"
@@ -49,7 +49,7 @@ func (b *BaseListener) Heartbeat(ctx context.Context, conn *websocket.Conn) erro
 			b.ConnMu.Unlock()
 
 			if err != nil {
-				slog.Debug("Ping failed", "error", err)
+				logger.Subsystem("ws").Debug("Ping failed", "error", err)
 				return err
 			}
 		}
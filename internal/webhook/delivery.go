@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type Delivery struct {
+	ID         string
+	DeliveryID string
+	Event      string
+	URL        string
+	ReceivedAt time.Time
+	// Headers are the request headers sent to URL, signature included.
+	Headers    map[string]string
+	StatusCode int
+	Response   []byte
+	Duration   time.Duration
+	Err        error
+}
+
+func (d Delivery) OK() bool {
+	return d.Err == nil && d.StatusCode >= 200 && d.StatusCode < 300
+}
+
+func parseMetadata(message []byte) (webhookMetadata, error) {
+	var raw struct {
+		ID    string `json:"id"`
+		Event string `json:"event"`
+		Data  struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(message, &raw); err != nil {
+		return webhookMetadata{}, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+
+	id := raw.Data.ID
+	if id == "" {
+		id = raw.ID
+	}
+
+	return webhookMetadata{Event: raw.Event, ID: id, DeliveryID: newDeliveryID(), ReceivedAt: time.Now()}, nil
+}
+
+// newDeliveryID returns a new ID for one delivery of an event. Every message
+// parsed is a new delivery, resends included.
+func newDeliveryID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return "dlv_" + hex.EncodeToString(b)
+}
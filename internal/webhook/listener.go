@@ -5,11 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log/slog"
 	"net/http"
 	"time"
 
 	"abacatepay-cli/internal/crypto"
+	"abacatepay-cli/internal/logger"
 	"abacatepay-cli/internal/style"
 	"abacatepay-cli/internal/ws"
 
@@ -23,7 +23,7 @@ func (l *Listener) Listen(ctx context.Context, mock bool) error {
 		return l.mockListen(ctx)
 	}
 
-	slog.Info("Starting webhook listener...")
+	logger.Subsystem("webhook").Info("Starting webhook listener...")
 
 	return ws.ConnectWithRetry(ctx, l.WSConfig(), l.readLoop)
 }
@@ -51,10 +51,12 @@ func (l *Listener) mockListen(ctx context.Context) error {
 			}
 
 			message, _ := json.Marshal(mockData)
-			l.displayWebhook(webhookMetadata{Event: event, ID: id}, message)
+			meta := webhookMetadata{Event: event, ID: id, DeliveryID: newDeliveryID(), ReceivedAt: time.Now()}
+			meta.Headers = l.signedHeaders(message)
+			l.displayWebhook(meta, message)
 
 			go func() {
-				_ = l.forward(ctx, message, event)
+				l.notify(l.forward(ctx, message, meta), message)
 			}()
 		}
 	}
@@ -68,6 +70,10 @@ func (l *Listener) readLoop(ctx context.Context, conn *websocket.Conn) error {
 
 	l.SetupConn(conn)
 
+	if l.hooks.OnConnect != nil {
+		l.hooks.OnConnect()
+	}
+
 	g.Go(func() error {
 		return l.Heartbeat(gCtx, conn)
 	})
@@ -85,7 +91,7 @@ func (l *Listener) readLoop(ctx context.Context, conn *websocket.Conn) error {
 		_, message, err := conn.ReadMessage()
 		if err != nil {
 			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
-				slog.Info("WebSocket connection closed")
+				logger.Subsystem("webhook").Info("WebSocket connection closed")
 				_ = g.Wait()
 				return nil
 			}
@@ -99,38 +105,67 @@ func (l *Listener) readLoop(ctx context.Context, conn *websocket.Conn) error {
 			return fmt.Errorf("failed to read websocket message: %w", err)
 		}
 
-		var raw struct {
-			Event string `json:"event"`
-			Data  struct {
-				ID string `json:"id"`
-			} `json:"data"`
-		}
-
-		if err := json.Unmarshal(message, &raw); err != nil {
+		meta, err := parseMetadata(message)
+		if err != nil {
 			style.PrintError("Received invalid JSON from WebSocket")
 			continue
 		}
 
-		meta := webhookMetadata{Event: raw.Event, ID: raw.Data.ID}
+		meta.Headers = l.signedHeaders(message)
 		l.displayWebhook(meta, message)
 
 		g.Go(func() error {
-			_ = l.forward(gCtx, message, meta.Event)
+			l.notify(l.forward(gCtx, message, meta), message)
 			return nil
 		})
 	}
 }
 
+// Deliver handles a single event as if it had arrived over the WebSocket: it is
+// displayed, written to the transaction log and forwarded synchronously.
+func (l *Listener) Deliver(ctx context.Context, message []byte) (Delivery, error) {
+	meta, err := parseMetadata(message)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	meta.Headers = l.signedHeaders(message)
+	l.displayWebhook(meta, message)
+
+	return l.forward(ctx, message, meta), nil
+}
+
+// Forward sends a previously received event to the forward URL again. Only the
+// outcome is logged, so the event's latest status in the log reflects the resend.
+func (l *Listener) Forward(ctx context.Context, message []byte) (Delivery, error) {
+	meta, err := parseMetadata(message)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	return l.forward(ctx, message, meta), nil
+}
+
+// Record writes an event to the transaction log without forwarding it, so it can
+// be delivered later with 'events resend'.
+func (l *Listener) Record(message []byte) error {
+	meta, err := parseMetadata(message)
+	if err != nil {
+		return err
+	}
+
+	l.logReceived(meta, message)
+	return nil
+}
+
 func (l *Listener) displayWebhook(meta webhookMetadata, rawBody []byte) {
 	style.LogWebhookReceived(meta.Event, meta.ID)
 
-	l.txLogger.Info("webhook_received",
-		"event", meta.Event,
-		"id", meta.ID,
-		"timestamp", time.Now().Format(time.RFC3339),
-		"size_bytes", len(rawBody),
-		"raw_message", string(rawBody),
-	)
+	l.logReceived(meta, rawBody)
+
+	if l.hooks.OnReceive != nil {
+		l.hooks.OnReceive(Delivery{ID: meta.ID, DeliveryID: meta.DeliveryID, Event: meta.Event, ReceivedAt: meta.ReceivedAt, Headers: meta.Headers}, rawBody)
+	}
 
 	if !l.Cfg.Verbose {
 		return
@@ -144,55 +179,115 @@ func (l *Listener) displayWebhook(meta webhookMetadata, rawBody []byte) {
 	fmt.Println(buf.String())
 }
 
-func (l *Listener) forward(ctx context.Context, message []byte, event string) error {
+func (l *Listener) logReceived(meta webhookMetadata, rawBody []byte) {
+	l.txLogger.Info("webhook_received",
+		"event", meta.Event,
+		"id", meta.ID,
+		"delivery_id", meta.DeliveryID,
+		"timestamp", time.Now().Format(time.RFC3339),
+		"size_bytes", len(rawBody),
+		"raw_message", string(rawBody),
+	)
+}
+
+func (l *Listener) notify(d Delivery, message []byte) {
+	if l.hooks.OnDelivery != nil {
+		l.hooks.OnDelivery(d, message)
+	}
+}
+
+func (l *Listener) forward(ctx context.Context, message []byte, meta webhookMetadata) Delivery {
+	event := meta.Event
+	delivery := Delivery{ID: meta.ID, DeliveryID: meta.DeliveryID, Event: event, URL: l.forwardURL, ReceivedAt: meta.ReceivedAt}
+
+	if l.forwardURL == "" {
+		return delivery
+	}
+
 	startTime := time.Now()
-	timestamp := time.Now().Unix()
 
-	signature := crypto.SignWebhookPayload(l.signingSecret, timestamp, message)
+	delivery.Headers = meta.Headers
+	if delivery.Headers == nil {
+		delivery.Headers = l.signedHeaders(message)
+	}
 
 	resp, err := l.client.R().
 		SetContext(ctx).
-		SetHeader("Content-Type", "application/json").
-		SetHeader("X-Abacate-Signature", fmt.Sprintf("t=%d,v1=%s", timestamp, signature)).
+		SetHeaders(delivery.Headers).
 		SetBody(message).
 		Post(l.forwardURL)
 
 	duration := time.Since(startTime)
+	delivery.Duration = duration
 
 	if err != nil {
 		l.txLogger.Error("webhook_forward_failed",
 			"event", event,
+			"id", meta.ID,
+			"delivery_id", meta.DeliveryID,
 			"url", l.forwardURL,
+			"signature", delivery.Headers[crypto.SignatureHeader],
 			"error", err.Error(),
 			"duration_ms", duration.Milliseconds(),
 			"timestamp", time.Now().Format(time.RFC3339),
 		)
-		return fmt.Errorf("failed to forward webhook: %w", err)
+		delivery.Err = fmt.Errorf("failed to forward webhook: %w", err)
+		return delivery
 	}
 
 	statusCode := resp.StatusCode()
+	delivery.StatusCode = statusCode
+	delivery.Response = resp.Body()
 	style.LogWebhookForwarded(statusCode, http.StatusText(statusCode), event)
 
 	if statusCode < 200 || statusCode >= 300 {
 		l.txLogger.Error("webhook_forward_error",
 			"event", event,
+			"id", meta.ID,
+			"delivery_id", meta.DeliveryID,
 			"url", l.forwardURL,
+			"signature", delivery.Headers[crypto.SignatureHeader],
 			"status_code", statusCode,
 			"duration_ms", duration.Milliseconds(),
-			"response_body", string(resp.Body()),
+			"response_body", loggedBody(resp.Body()),
 			"timestamp", time.Now().Format(time.RFC3339),
 		)
-		return nil
+		return delivery
 	}
 
 	l.txLogger.Info("webhook_forwarded",
 		"event", event,
+		"id", meta.ID,
+		"delivery_id", meta.DeliveryID,
 		"url", l.forwardURL,
+		"signature", delivery.Headers[crypto.SignatureHeader],
 		"status_code", statusCode,
 		"duration_ms", duration.Milliseconds(),
+		"response_body", loggedBody(resp.Body()),
 		"timestamp", time.Now().Format(time.RFC3339),
 		"size_bytes", len(message),
 	)
 
-	return nil
+	return delivery
+}
+
+// signedHeaders are the headers an event is forwarded with, signed now.
+func (l *Listener) signedHeaders(message []byte) map[string]string {
+	timestamp := time.Now().Unix()
+	signature := crypto.SignWebhookPayload(l.signingSecret, timestamp, message)
+
+	return map[string]string{
+		"Content-Type":         "application/json",
+		crypto.SignatureHeader: crypto.FormatSignatureHeader(timestamp, signature),
+	}
+}
+
+// maxLoggedBody caps the response bodies written to the transaction log.
+const maxLoggedBody = 64 << 10
+
+func loggedBody(body []byte) string {
+	if len(body) > maxLoggedBody {
+		return string(body[:maxLoggedBody]) + "...(truncated)"
+	}
+	return string(body)
 }
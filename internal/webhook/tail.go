@@ -5,9 +5,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log/slog"
 
 	"abacatepay-cli/internal/config"
+	"abacatepay-cli/internal/logger"
 	"abacatepay-cli/internal/style"
 	"abacatepay-cli/internal/ws"
 
@@ -28,7 +28,7 @@ func NewTailListener(cfg *config.Config, token string) *TailListener {
 }
 
 func (t *TailListener) Listen(ctx context.Context) error {
-	slog.Info("Starting tail listener...")
+	logger.Subsystem("webhook").Info("Starting tail listener...")
 
 	return ws.ConnectWithRetry(ctx, t.WSConfig(), t.readLoop)
 }
@@ -52,7 +52,7 @@ func (t *TailListener) readLoop(ctx context.Context, conn *websocket.Conn) error
 		_, message, err := conn.ReadMessage()
 		if err != nil {
 			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
-				slog.Info("WebSocket connection closed")
+				logger.Subsystem("webhook").Info("WebSocket connection closed")
 				return nil
 			}
 
@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type Received struct {
+	Delivery Delivery
+	Message  []byte
+}
+
+// Waiter runs a Listener in the background and lets callers block until an
+// event matching their criteria has been received (and forwarded, if the
+// listener has a forward URL). Events that don't match are kept for later waits.
+type Waiter struct {
+	received  chan Received
+	done      chan struct{}
+	ready     chan struct{}
+	readyOnce sync.Once
+	err       error
+	backlog   []Received
+}
+
+func StartWaiter(ctx context.Context, l *Listener) *Waiter {
+	w := &Waiter{
+		received: make(chan Received, 64),
+		done:     make(chan struct{}),
+		ready:    make(chan struct{}),
+	}
+
+	l.SetHooks(Hooks{
+		OnConnect: func() {
+			w.readyOnce.Do(func() { close(w.ready) })
+		},
+		OnDelivery: func(d Delivery, message []byte) {
+			select {
+			case w.received <- Received{Delivery: d, Message: message}:
+			case <-ctx.Done():
+			}
+		},
+	})
+
+	go func() {
+		defer close(w.done)
+		w.err = l.Listen(ctx, false)
+	}()
+
+	return w
+}
+
+// Ready blocks until the WebSocket is connected, so events triggered afterwards
+// can't be missed.
+func (w *Waiter) Ready(ctx context.Context, timeout time.Duration) error {
+	select {
+	case <-w.ready:
+		return nil
+	case <-w.done:
+		return w.listenErr()
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(timeout):
+		return fmt.Errorf("timed out after %s waiting for the WebSocket connection", timeout)
+	}
+}
+
+func (w *Waiter) Wait(ctx context.Context, timeout time.Duration, match func(Received) bool) (Received, error) {
+	for i, r := range w.backlog {
+		if match(r) {
+			w.backlog = append(w.backlog[:i], w.backlog[i+1:]...)
+			return r, nil
+		}
+	}
+
+	deadline := time.After(timeout)
+
+	for {
+		select {
+		case r := <-w.received:
+			if match(r) {
+				return r, nil
+			}
+			w.backlog = append(w.backlog, r)
+		case <-w.done:
+			return Received{}, w.listenErr()
+		case <-ctx.Done():
+			return Received{}, ctx.Err()
+		case <-deadline:
+			return Received{}, fmt.Errorf("no matching webhook received within %s", timeout)
+		}
+	}
+}
+
+func (w *Waiter) listenErr() error {
+	if w.err == nil || errors.Is(w.err, context.Canceled) {
+		return fmt.Errorf("webhook listener stopped")
+	}
+	return fmt.Errorf("webhook listener stopped: %w", w.err)
+}
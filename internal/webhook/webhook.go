@@ -19,6 +19,22 @@ type Message struct {
 type webhookMetadata struct {
 	Event string
 	ID    string
+	// DeliveryID ties together the log lines of one delivery of the event.
+	DeliveryID string
+	ReceivedAt time.Time
+	// Headers are the signed headers the event is forwarded with, set when it
+	// is received so they can be recorded before forwarding.
+	Headers map[string]string
+}
+
+// Hooks lets callers observe a running Listener. OnConnect runs every time the
+// WebSocket (re)connects. OnReceive runs for each event as it arrives, in order
+// and before it is forwarded, with only the fields known at that point set;
+// OnDelivery runs once the event was forwarded.
+type Hooks struct {
+	OnConnect  func()
+	OnReceive  func(d Delivery, message []byte)
+	OnDelivery func(d Delivery, message []byte)
 }
 
 type Listener struct {
@@ -27,6 +43,7 @@ type Listener struct {
 	forwardURL    string
 	txLogger      *slog.Logger
 	signingSecret string
+	hooks         Hooks
 }
 
 func NewListener(cfg *config.Config, client *resty.Client, forwardURL, token string, txLogger *slog.Logger) *Listener {
@@ -41,3 +58,11 @@ func NewListener(cfg *config.Config, client *resty.Client, forwardURL, token str
 		signingSecret: "whsec_mock_" + hex.EncodeToString([]byte(time.Now().Format("150405"))),
 	}
 }
+
+func (l *Listener) SetSigningSecret(secret string) {
+	l.signingSecret = secret
+}
+
+func (l *Listener) SetHooks(hooks Hooks) {
+	l.hooks = hooks
+}
@@ -3,10 +3,10 @@ package ws
 import (
 	"context"
 	"fmt"
-	"log/slog"
 	"net/http"
 	"time"
 
+	"abacatepay-cli/internal/logger"
 	"abacatepay-cli/internal/style"
 
 	"github.com/gorilla/websocket"
@@ -33,7 +33,7 @@ func ConnectWithRetry(ctx context.Context, cfg Config, handler Handler) error {
 		default:
 		}
 
-		slog.Debug("Connecting...", "url", cfg.URL)
+		logger.Subsystem("ws").Debug("Connecting...", "url", cfg.URL)
 
 		conn, _, err := websocket.DefaultDialer.DialContext(ctx, cfg.URL, cfg.Headers)
 		if err != nil {
@@ -45,7 +45,7 @@ func ConnectWithRetry(ctx context.Context, cfg Config, handler Handler) error {
 				return fmt.Errorf("%s", errMsg)
 			}
 
-			slog.Warn(
+			logger.Subsystem("ws").Warn(
 				"Connection failed, retrying…",
 				"error", err,
 				"backoff", backoff,
@@ -64,12 +64,12 @@ func ConnectWithRetry(ctx context.Context, cfg Config, handler Handler) error {
 			}
 		}
 
-		slog.Info("WebSocket connected")
+		logger.Subsystem("ws").Info("WebSocket connected")
 		backoff = cfg.MinBackoff
 		retries = 0
 
 		if err := handler(ctx, conn); err != nil {
-			slog.Warn("Connection lost", "error", err)
+			logger.Subsystem("ws").Warn("Connection lost", "error", err)
 		}
 
 		conn.Close()